@@ -0,0 +1,115 @@
+package reflectme
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// isCollectionPointer indicates if obj points to a slice, an array or
+// a map other than map[string]interface{}, which is copied field by field
+func isCollectionPointer(obj interface{}) bool {
+	t := reflect.TypeOf(obj).Elem()
+	return t.Kind() == reflect.Slice || t.Kind() == reflect.Array || (t.Kind() == reflect.Map && t != mapType)
+}
+
+// copyCollection copies the from slice, array or map into the collection
+// pointed by to, creating the destination elements
+func copyCollection(from interface{}, to interface{}, options CopyOptions) error {
+	if from == nil {
+		return fmt.Errorf("Cannot copy nil into %v", reflect.TypeOf(to).Elem())
+	}
+
+	toValue := reflect.ValueOf(to).Elem()
+	v, err := copyValue(reflect.ValueOf(from), toValue.Type(), options)
+	if err != nil {
+		return err
+	}
+	toValue.Set(v)
+
+	return nil
+}
+
+// copyField sets the to field, copying slices and maps element by
+// element when their types differ
+func copyField(to interface{}, name string, value interface{}, options CopyOptions) error {
+	t, err := resolveTypePath(reflect.TypeOf(to), name, PathOptions{})
+	if err == nil && value != nil && reflect.TypeOf(value) != t && isCollectionKind(t.Kind()) {
+		v, err := copyValue(reflect.ValueOf(value), t, options)
+		if err != nil {
+			return fmt.Errorf("Cannot copy field %s: %v", name, err)
+		}
+		value = v.Interface()
+	}
+
+	return SetField(to, name, value)
+}
+
+// copyValue returns from as a new t value. Collections are copied element
+// by element and structs are copied with CopyWithOptions.
+func copyValue(from reflect.Value, t reflect.Type, options CopyOptions) (reflect.Value, error) {
+	if from.Kind() == reflect.Interface {
+		from = from.Elem()
+	}
+	if !from.IsValid() {
+		return convertValue(nil, t)
+	}
+	fromKind := from.Kind()
+
+	switch {
+	case (fromKind == reflect.Slice || fromKind == reflect.Array) && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array):
+		if fromKind == reflect.Slice && from.IsNil() {
+			return reflect.Zero(t), nil
+		}
+		to := reflect.New(t).Elem()
+		n := from.Len()
+		if t.Kind() == reflect.Slice {
+			to.Set(reflect.MakeSlice(t, n, n))
+		} else if t.Len() < n {
+			n = t.Len()
+		}
+		for i := 0; i < n; i++ {
+			elem, err := copyValue(from.Index(i), t.Elem(), options)
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("Cannot copy element %d: %v", i, err)
+			}
+			to.Index(i).Set(elem)
+		}
+		return to, nil
+	case fromKind == reflect.Map && t.Kind() == reflect.Map:
+		if from.IsNil() {
+			return reflect.Zero(t), nil
+		}
+		to := reflect.MakeMapWithSize(t, from.Len())
+		iter := from.MapRange()
+		for iter.Next() {
+			key, err := convertValue(iter.Key().Interface(), t.Key())
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("Cannot copy key %v: %v", iter.Key(), err)
+			}
+			elem, err := copyValue(iter.Value(), t.Elem(), options)
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("Cannot copy key %v: %v", iter.Key(), err)
+			}
+			to.SetMapIndex(key, elem)
+		}
+		return to, nil
+	case from.Type().AssignableTo(t):
+		return from, nil
+	case structType(t) != nil && (structType(from.Type()) != nil || isStringMap(from.Interface())):
+		if fromKind == reflect.Ptr && from.IsNil() {
+			return reflect.Zero(t), nil
+		}
+		to := reflect.New(structType(t))
+		// Elements are reported as a whole by the parent copy
+		options.report = nil
+		if err := CopyWithOptions(from.Interface(), to.Interface(), options); err != nil {
+			return reflect.Value{}, err
+		}
+		if t.Kind() == reflect.Ptr {
+			return to, nil
+		}
+		return to.Elem(), nil
+	}
+
+	return reflect.Value{}, fmt.Errorf("Provided value type (%v) didn't match obj field type (%v)", from.Type(), t)
+}
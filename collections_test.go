@@ -0,0 +1,127 @@
+package reflectme
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TestModel struct {
+	ID       int
+	Name     string
+	Internal string
+	Children []TestModel
+	Tags     map[string]NestedStruct
+}
+
+type TestDTO struct {
+	ID       int
+	Name     string
+	Children []*TestDTO
+	Tags     map[string]TestDummyOnlyStruct
+}
+
+func TestCopy_slice_of_structs(t *testing.T) {
+	from := []TestModel{
+		{ID: 1, Name: "one", Internal: "secret"},
+		{ID: 2, Name: "two", Children: []TestModel{{ID: 3}}},
+	}
+	var to []TestDTO
+
+	err := CopyWithOptions(from, &to, CopyOptions{CopyZeroValues: true, IgnoreNotFoundFields: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []TestDTO{
+		{ID: 1, Name: "one"},
+		{ID: 2, Name: "two", Children: []*TestDTO{{ID: 3}}},
+	}, to)
+}
+
+func TestCopy_slice_of_struct_pointers(t *testing.T) {
+	from := []*TestModel{{ID: 1}, nil}
+	var to []*TestDTO
+
+	err := Copy(from, &to)
+	assert.NoError(t, err)
+	assert.Equal(t, []*TestDTO{{ID: 1}, nil}, to)
+}
+
+func TestCopy_slice_of_same_type_creates_new_slice(t *testing.T) {
+	from := []int{1, 2}
+	var to []int
+
+	err := Copy(from, &to)
+	assert.NoError(t, err)
+	to[0] = 10
+	assert.Equal(t, []int{1, 2}, from)
+	assert.Equal(t, []int{10, 2}, to)
+
+	var nilTo []int
+	err = Copy([]int(nil), &nilTo)
+	assert.NoError(t, err)
+	assert.Nil(t, nilTo)
+}
+
+func TestCopy_into_array(t *testing.T) {
+	var to [2]TestDTO
+
+	err := Copy([3]TestModel{{ID: 1}, {ID: 2}, {ID: 3}}, &to)
+	assert.NoError(t, err)
+	assert.Equal(t, [2]TestDTO{{ID: 1}, {ID: 2}}, to)
+}
+
+func TestCopy_map_of_structs(t *testing.T) {
+	from := map[int]TestModel{1: {ID: 1, Name: "one"}}
+	var to map[int64]*TestDTO
+
+	err := Copy(from, &to)
+	assert.NoError(t, err)
+	assert.Equal(t, map[int64]*TestDTO{1: {ID: 1, Name: "one"}}, to)
+
+	var nilTo map[int]TestDTO
+	err = Copy(map[int]TestModel(nil), &nilTo)
+	assert.NoError(t, err)
+	assert.Nil(t, nilTo)
+}
+
+func TestCopy_nested_map_field_element_wise(t *testing.T) {
+	from := TestModel{Tags: map[string]NestedStruct{"a": {Dummy: "tag"}}}
+	to := TestDTO{}
+
+	err := CopyWithOptions(from, &to, CopyOptions{CopyZeroValues: true, IgnoreNotFoundFields: true})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]TestDummyOnlyStruct{"a": {Dummy: "tag"}}, to.Tags)
+}
+
+func TestCopy_slice_of_maps_into_structs(t *testing.T) {
+	from := []interface{}{
+		map[string]interface{}{"ID": 1.0, "Name": "one"},
+		nil,
+	}
+	var to []*TestDTO
+
+	err := Copy(from, &to)
+	assert.NoError(t, err)
+	assert.Equal(t, []*TestDTO{{ID: 1, Name: "one"}, nil}, to)
+}
+
+func TestCopy_collections_with_errors(t *testing.T) {
+	var to []TestDTO
+	assert.Error(t, Copy(nil, &to))
+	assert.Error(t, Copy([]string{"a"}, &to))
+	assert.Error(t, Copy([]interface{}{nil}, &to))
+	assert.Error(t, Copy("test", &to))
+
+	var toMap map[int]TestDTO
+	assert.Error(t, Copy(map[string]TestModel{"a": {}}, &toMap))
+	assert.Error(t, Copy(map[int]string{1: "a"}, &toMap))
+
+	var toStrict []TestStruct
+	err := CopyWithOptions([]TestStructDifferentType{{DateTime: "now"}}, &toStrict, CopyOptions{})
+	assert.Error(t, err)
+
+	toStruct := TestDTO{}
+	err = CopyWithOptions(TestModel{Children: []TestModel{{Internal: "a"}}}, &toStruct, CopyOptions{})
+	assert.Error(t, err)
+	err = CopyWithOptions(struct{ Children []string }{[]string{"a"}}, &toStruct, CopyOptions{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,228 @@
+package reflectme
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type (
+	// DumpOptions are options for dump function
+	DumpOptions struct {
+		// ShowTypes prints the type of every path
+		ShowTypes bool
+		// ShowAddresses prints the pointer addresses
+		ShowAddresses bool
+		// MaxDepth is the maximum nesting level printed, 0 means no
+		// limit. Deeper structs and collections are collapsed.
+		MaxDepth int
+		// MaxItems is the maximum number of slice, array and map elements
+		// printed, 0 means no limit. The rest are collapsed.
+		MaxItems int
+	}
+
+	dumper struct {
+		w       io.Writer
+		err     error
+		options DumpOptions
+		visited map[visitedPointer]string
+	}
+)
+
+// DefaultDumpOptions are the default options for dump function
+var DefaultDumpOptions = DumpOptions{ShowTypes: true, MaxItems: 10}
+
+// Dump writes obj to w as an indented tree, one path per line with the
+// same paths FieldsNames and GetField use, eg "Nested.Name",
+// "Items[0].ID" or "Labels[key]". The first line is the obj type.
+// Pointers and interfaces are shown with the values they hold, pointers,
+// maps and slices already printed in the current path are marked as
+// cycles, values implementing fmt.Stringer or error, like time.Time, are
+// printed with their String or Error method and unexported fields are
+// skipped.
+func Dump(w io.Writer, obj interface{}, options DumpOptions) error {
+	d := &dumper{w: w, options: options, visited: make(map[visitedPointer]string)}
+	d.dump(reflect.ValueOf(obj), "", 0)
+
+	return d.err
+}
+
+func (d *dumper) dump(v reflect.Value, path string, level int) {
+	if !v.IsValid() {
+		d.line(level, path, nil, "", " = nil")
+		return
+	}
+
+	t := v.Type()
+	var addr string
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			d.line(level, path, t, addr, " = nil")
+			return
+		}
+		if v.Kind() == reflect.Interface {
+			v = v.Elem()
+			t = v.Type()
+			continue
+		}
+		if isDumpedAsString(v.Type()) {
+			break
+		}
+
+		if d.options.ShowAddresses {
+			addr = fmt.Sprintf(" %#x", v.Pointer())
+		}
+		key := visitedPointer{v.Pointer(), v.Type()}
+		if d.isCycle(key, path, level, t, addr) {
+			return
+		}
+		d.visited[key] = path
+		defer delete(d.visited, key)
+		v = v.Elem()
+	}
+	// Maps and slices can hold themselves through interfaces
+	if (v.Kind() == reflect.Map || v.Kind() == reflect.Slice) && !v.IsNil() {
+		key := visitedPointer{v.Pointer(), v.Type()}
+		if d.isCycle(key, path, level, t, addr) {
+			return
+		}
+		d.visited[key] = path
+		defer delete(d.visited, key)
+	}
+
+	if isDumpedAsString(v.Type()) {
+		d.line(level, path, t, addr, " = "+fmt.Sprint(v.Interface()))
+		return
+	}
+	if isOptionalType(v.Type()) {
+		value, state := v.Interface().(optional).optionalValue()
+		switch state {
+		case optionalAbsent:
+			d.line(level, path, t, addr, " = absent")
+		case optionalNull:
+			d.line(level, path, t, addr, " = null")
+		default:
+			d.dump(reflect.ValueOf(value), path, level)
+		}
+		return
+	}
+
+	collapsed := d.options.MaxDepth > 0 && level >= d.options.MaxDepth
+	switch v.Kind() {
+	case reflect.Struct:
+		if collapsed {
+			d.line(level, path, t, addr, " {...}")
+			return
+		}
+		d.line(level, path, t, addr, "")
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !isExportableField(field) {
+				continue
+			}
+			fieldPath := field.Name
+			if len(path) > 0 {
+				fieldPath = path + "." + field.Name
+			}
+			d.dump(v.Field(i), fieldPath, level+1)
+		}
+	case reflect.Slice, reflect.Array, reflect.Map:
+		if v.Kind() != reflect.Array && v.IsNil() {
+			d.line(level, path, t, addr, " = nil")
+			return
+		}
+		suffix := fmt.Sprintf(" len=%d", v.Len())
+		if collapsed && v.Len() > 0 {
+			d.line(level, path, t, addr, suffix+" [...]")
+			return
+		}
+		d.line(level, path, t, addr, suffix)
+		d.dumpElements(v, path, level+1)
+	case reflect.String:
+		d.line(level, path, t, addr, " = "+strconv.Quote(v.String()))
+	default:
+		d.line(level, path, t, addr, fmt.Sprintf(" = %v", v.Interface()))
+	}
+}
+
+// isCycle indicates if the key was already printed in the current path,
+// printing the cycle line when it was
+func (d *dumper) isCycle(key visitedPointer, path string, level int, t reflect.Type, addr string) bool {
+	target, ok := d.visited[key]
+	if ok {
+		d.line(level, path, t, addr, fmt.Sprintf(" <cycle to %s>", dumpPathName(target)))
+	}
+
+	return ok
+}
+
+// dumpElements prints the v collection elements up to MaxItems, with
+// map keys sorted like FieldsNames does
+func (d *dumper) dumpElements(v reflect.Value, path string, level int) {
+	n := v.Len()
+	if d.options.MaxItems > 0 && n > d.options.MaxItems {
+		n = d.options.MaxItems
+	}
+
+	if v.Kind() == reflect.Map {
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+		})
+		for _, key := range keys[:n] {
+			d.dump(v.MapIndex(key), fmt.Sprintf("%s[%v]", path, key), level)
+		}
+	} else {
+		for i := 0; i < n; i++ {
+			d.dump(v.Index(i), fmt.Sprintf("%s[%d]", path, i), level)
+		}
+	}
+
+	if more := v.Len() - n; more > 0 {
+		d.printf("%s... %d more\n", strings.Repeat("  ", level), more)
+	}
+}
+
+// line prints a path line. The root line has no path and always shows
+// its type.
+func (d *dumper) line(level int, path string, t reflect.Type, addr, suffix string) {
+	var b strings.Builder
+	b.WriteString(strings.Repeat("  ", level))
+	b.WriteString(path)
+	switch {
+	case level == 0 && t == nil:
+		b.WriteString("nil")
+		suffix = ""
+	case level == 0:
+		b.WriteString(t.String())
+	case t != nil && d.options.ShowTypes:
+		fmt.Fprintf(&b, " (%v)", t)
+	}
+	b.WriteString(addr)
+	b.WriteString(suffix)
+
+	d.printf("%s\n", b.String())
+}
+
+func (d *dumper) printf(format string, args ...interface{}) {
+	if d.err == nil {
+		_, d.err = fmt.Fprintf(d.w, format, args...)
+	}
+}
+
+// isDumpedAsString indicates if t values are printed with their String or
+// Error method
+func isDumpedAsString(t reflect.Type) bool {
+	return t.Implements(stringerType) || t.Implements(errorType)
+}
+
+func dumpPathName(path string) string {
+	if len(path) == 0 {
+		return "root"
+	}
+
+	return path
+}
@@ -0,0 +1,205 @@
+package reflectme
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TestDumpItem struct {
+	ID int
+}
+
+type TestDumpStruct struct {
+	Name   string
+	Nested *NestedStruct
+	Next   *TestDumpStruct
+	secret string
+}
+
+type TestDumpWriter struct {
+	n int
+}
+
+func (w *TestDumpWriter) Write(p []byte) (int, error) {
+	if w.n == 0 {
+		return 0, errors.New("write")
+	}
+	w.n--
+	return len(p), nil
+}
+
+func dumpString(t *testing.T, obj interface{}, options DumpOptions) string {
+	var buf bytes.Buffer
+	assert.NoError(t, Dump(&buf, obj, options))
+
+	return buf.String()
+}
+
+func TestDump_on_struct(t *testing.T) {
+	obj := TestDumpStruct{Name: "name", Nested: &NestedStruct{Dummy: "dummy"}, secret: "secret"}
+
+	assert.Equal(t, `reflectme.TestDumpStruct
+  Name (string) = "name"
+  Nested (*reflectme.NestedStruct)
+    Nested.Dummy (string) = "dummy"
+    Nested.Yummy (int) = 0
+  Next (*reflectme.TestDumpStruct) = nil
+`, dumpString(t, obj, DefaultDumpOptions))
+}
+
+func TestDump_on_interfaces(t *testing.T) {
+	obj := struct {
+		Any   interface{}
+		Empty interface{}
+	}{Any: 1.5}
+
+	assert.Equal(t, `struct { Any interface {}; Empty interface {} }
+  Any (float64) = 1.5
+  Empty (interface {}) = nil
+`, dumpString(t, obj, DefaultDumpOptions))
+}
+
+func TestDump_on_slices_and_arrays(t *testing.T) {
+	obj := struct {
+		Items  []TestDumpItem
+		Tags   []string
+		Matrix [2]int
+	}{Items: []TestDumpItem{{ID: 1}}, Matrix: [2]int{1, 2}}
+
+	assert.Equal(t, `struct { Items []reflectme.TestDumpItem; Tags []string; Matrix [2]int }
+  Items ([]reflectme.TestDumpItem) len=1
+    Items[0] (reflectme.TestDumpItem)
+      Items[0].ID (int) = 1
+  Tags ([]string) = nil
+  Matrix ([2]int) len=2
+    Matrix[0] (int) = 1
+    Matrix[1] (int) = 2
+`, dumpString(t, obj, DefaultDumpOptions))
+}
+
+func TestDump_on_maps(t *testing.T) {
+	labels := map[string]int{"b": 2, "a": 1}
+
+	assert.Equal(t, `map[string]int len=2
+  [a] (int) = 1
+  [b] (int) = 2
+`, dumpString(t, labels, DumpOptions{ShowTypes: true}))
+}
+
+func TestDump_on_stringers(t *testing.T) {
+	obj := struct {
+		CreatedAt time.Time
+		Err       error
+	}{CreatedAt: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), Err: errors.New("failure")}
+
+	assert.Equal(t, `struct { CreatedAt time.Time; Err error }
+  CreatedAt = 2020-01-01 00:00:00 +0000 UTC
+  Err = failure
+`, dumpString(t, obj, DumpOptions{}))
+}
+
+func TestDump_on_optionals(t *testing.T) {
+	obj := struct {
+		Note  Optional[string]
+		Age   Optional[int]
+		Score Optional[float64]
+	}{Note: Some("note"), Age: Null[int]()}
+
+	assert.Equal(t, `struct { Note reflectme.Optional[string]; Age reflectme.Optional[int]; Score reflectme.Optional[float64] }
+  Note (string) = "note"
+  Age (reflectme.Optional[int]) = null
+  Score (reflectme.Optional[float64]) = absent
+`, dumpString(t, obj, DefaultDumpOptions))
+}
+
+func TestDump_with_max_items(t *testing.T) {
+	items := []int{1, 2, 3}
+
+	assert.Equal(t, `[]int len=3
+  [0] = 1
+  [1] = 2
+  ... 1 more
+`, dumpString(t, items, DumpOptions{MaxItems: 2}))
+}
+
+func TestDump_with_max_depth(t *testing.T) {
+	obj := struct {
+		Nested NestedStruct
+		Items  []int
+		Empty  []int
+	}{Items: []int{1}, Empty: []int{}}
+
+	assert.Equal(t, `struct { Nested reflectme.NestedStruct; Items []int; Empty []int }
+  Nested {...}
+  Items len=1 [...]
+  Empty len=0
+`, dumpString(t, obj, DumpOptions{MaxDepth: 1}))
+}
+
+func TestDump_with_show_addresses(t *testing.T) {
+	obj := &TestDumpStruct{Nested: &NestedStruct{}}
+
+	assert.Equal(t, fmt.Sprintf(`*reflectme.TestDumpStruct %p
+  Name = ""
+  Nested %p {...}
+  Next = nil
+`, obj, obj.Nested), dumpString(t, obj, DumpOptions{ShowAddresses: true, MaxDepth: 1}))
+}
+
+func TestDump_on_pointer_cycles(t *testing.T) {
+	obj := &TestDumpStruct{}
+	obj.Next = obj
+	assert.Equal(t, `*reflectme.TestDumpStruct
+  Name = ""
+  Nested = nil
+  Next <cycle to root>
+`, dumpString(t, obj, DumpOptions{}))
+
+	// Pointers printed in other paths aren't cycles
+	nested := &NestedStruct{}
+	other := struct{ First, Second *NestedStruct }{nested, nested}
+	assert.NotContains(t, dumpString(t, other, DumpOptions{}), "cycle")
+}
+
+func TestDump_on_map_cycles(t *testing.T) {
+	m := map[string]interface{}{"name": "name"}
+	m["self"] = m
+
+	assert.Equal(t, `map[string]interface {} len=2
+  [name] = "name"
+  [self] <cycle to root>
+`, dumpString(t, m, DumpOptions{}))
+}
+
+func TestDump_on_slice_cycles(t *testing.T) {
+	s := []interface{}{1, nil}
+	s[1] = s
+
+	assert.Equal(t, `struct { Items []interface {} }
+  Items len=2
+    Items[0] = 1
+    Items[1] <cycle to Items>
+`, dumpString(t, struct{ Items []interface{} }{s}, DumpOptions{}))
+}
+
+func TestDump_on_values(t *testing.T) {
+	cases := map[interface{}]string{
+		nil:                   "nil\n",
+		"name":                "string = \"name\"\n",
+		errors.New("failure"): "*errors.errorString = failure\n",
+		(*NestedStruct)(nil):  "*reflectme.NestedStruct = nil\n",
+	}
+	for obj, expected := range cases {
+		assert.Equal(t, expected, dumpString(t, obj, DefaultDumpOptions))
+	}
+}
+
+func TestDump_on_write_error(t *testing.T) {
+	err := Dump(&TestDumpWriter{n: 1}, TestDumpStruct{}, DefaultDumpOptions)
+	assert.EqualError(t, err, "write")
+}
@@ -0,0 +1,215 @@
+package reflectme
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+)
+
+type (
+	// EqualOptions are options for equal function
+	EqualOptions struct {
+		// IgnorePaths are not compared. A path matches its nested paths
+		// too and "*" matches any path segment, eg "Address", "Items[*].ID"
+		// or "Labels.*".
+		IgnorePaths []string
+		// IgnoreUnexported skips the unexported struct fields. Structs
+		// with an Equal(T) bool method, like time.Time, are compared with
+		// it instead.
+		IgnoreUnexported bool
+		// NilEqualsEmpty considers nil and empty slices and maps equal
+		NilEqualsEmpty bool
+		// FloatTolerance is the maximum difference between equal floats
+		FloatTolerance float64
+		// IgnoreOrder compares slices and arrays as unordered collections
+		IgnoreOrder bool
+		// Comparers compare the values of their types instead of the
+		// default comparison, eg time.Time values with their Equal method
+		Comparers map[reflect.Type]func(a, b interface{}) bool
+	}
+
+	equalVisit struct {
+		a   uintptr
+		b   uintptr
+		typ reflect.Type
+	}
+
+	equaler struct {
+		options EqualOptions
+		ignore  []string
+		visited map[equalVisit]bool
+	}
+)
+
+var pathIndexReplacer = strings.NewReplacer("[", ".", "]", "")
+
+// Equal indicates if a and b are deeply equal with EqualOptions. Without
+// options, it behaves like reflect.DeepEqual. Paths are built like
+// "Items[0].Name" and "Labels[key]".
+func Equal(a interface{}, b interface{}, options EqualOptions) bool {
+	e := &equaler{options: options, visited: make(map[equalVisit]bool)}
+	for _, path := range options.IgnorePaths {
+		e.ignore = append(e.ignore, pathIndexReplacer.Replace(path))
+	}
+
+	return e.equal(reflect.ValueOf(a), reflect.ValueOf(b), "")
+}
+
+func (e *equaler) equal(a, b reflect.Value, path string) bool {
+	if len(path) > 0 && len(e.ignore) > 0 && matchesAnyPath(e.ignore, pathIndexReplacer.Replace(path)) {
+		return true
+	}
+	if !a.IsValid() || !b.IsValid() {
+		return a.IsValid() == b.IsValid()
+	}
+	if a.Type() != b.Type() {
+		return false
+	}
+	if comparer, ok := e.options.Comparers[a.Type()]; ok && a.CanInterface() {
+		return comparer(a.Interface(), b.Interface())
+	}
+
+	// Pointers, maps and slices compared in the current path are equal,
+	// which stops cycles
+	if kind := a.Kind(); (kind == reflect.Ptr || kind == reflect.Map || kind == reflect.Slice) && !a.IsNil() && !b.IsNil() {
+		visit := equalVisit{a.Pointer(), b.Pointer(), a.Type()}
+		if e.visited[visit] {
+			return true
+		}
+		e.visited[visit] = true
+		defer delete(e.visited, visit)
+	}
+
+	switch a.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		if a.Kind() == reflect.Ptr && a.Pointer() == b.Pointer() {
+			return true
+		}
+		return e.equal(a.Elem(), b.Elem(), path)
+	case reflect.Struct:
+		if isOptionalType(a.Type()) && a.CanInterface() {
+			return e.equalOptionals(a, b, path)
+		}
+		if e.options.IgnoreUnexported {
+			// Fields holding a are exported, so its methods can be called
+			if equal, ok := equalMethod(a, b); ok {
+				return equal
+			}
+		}
+		for i := 0; i < a.NumField(); i++ {
+			field := a.Type().Field(i)
+			if e.options.IgnoreUnexported && !isExportableField(field) {
+				continue
+			}
+			fieldPath := field.Name
+			if len(path) > 0 {
+				fieldPath = path + "." + field.Name
+			}
+			if !e.equal(a.Field(i), b.Field(i), fieldPath) {
+				return false
+			}
+		}
+		return true
+	case reflect.Slice, reflect.Array:
+		if a.Kind() == reflect.Slice && a.IsNil() != b.IsNil() && !e.options.NilEqualsEmpty {
+			return false
+		}
+		if a.Len() != b.Len() {
+			return false
+		}
+		if e.options.IgnoreOrder {
+			return e.equalUnordered(a, b, path)
+		}
+		for i := 0; i < a.Len(); i++ {
+			if !e.equal(a.Index(i), b.Index(i), fmt.Sprintf("%s[%d]", path, i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Map:
+		if a.IsNil() != b.IsNil() && !e.options.NilEqualsEmpty {
+			return false
+		}
+		if a.Len() != b.Len() {
+			return false
+		}
+		iter := a.MapRange()
+		for iter.Next() {
+			bValue := b.MapIndex(iter.Key())
+			if !bValue.IsValid() || !e.equal(iter.Value(), bValue, fmt.Sprintf("%s[%v]", path, iter.Key())) {
+				return false
+			}
+		}
+		return true
+	case reflect.Func:
+		// Like reflect.DeepEqual, only nil funcs are equal
+		return a.IsNil() && b.IsNil()
+	case reflect.Float32, reflect.Float64:
+		return e.equalFloats(a.Float(), b.Float())
+	case reflect.Complex64, reflect.Complex128:
+		return e.equalFloats(real(a.Complex()), real(b.Complex())) && e.equalFloats(imag(a.Complex()), imag(b.Complex()))
+	case reflect.Bool:
+		return a.Bool() == b.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() == b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() == b.Uint()
+	case reflect.String:
+		return a.String() == b.String()
+	}
+
+	// Channels and unsafe pointers are equal when they are the same
+	return a.Pointer() == b.Pointer()
+}
+
+// equalUnordered indicates if every a element is equal to a distinct
+// b element
+func (e *equaler) equalUnordered(a, b reflect.Value, path string) bool {
+	matched := make([]bool, b.Len())
+	for i := 0; i < a.Len(); i++ {
+		elemPath := fmt.Sprintf("%s[%d]", path, i)
+		found := false
+		for j := 0; j < b.Len() && !found; j++ {
+			if !matched[j] && e.equal(a.Index(i), b.Index(j), elemPath) {
+				matched[j], found = true, true
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// equalOptionals indicates if the a and b Optional values have the same
+// state and equal values
+func (e *equaler) equalOptionals(a, b reflect.Value, path string) bool {
+	aValue, aState := a.Interface().(optional).optionalValue()
+	bValue, bState := b.Interface().(optional).optionalValue()
+
+	return aState == bState && e.equal(reflect.ValueOf(aValue), reflect.ValueOf(bValue), path)
+}
+
+// equalMethod returns the result of the a Equal(T) bool method with b,
+// if it has one
+func equalMethod(a, b reflect.Value) (bool, bool) {
+	method := a.MethodByName("Equal")
+	if !method.IsValid() {
+		return false, false
+	}
+	t := method.Type()
+	if t.NumIn() != 1 || t.In(0) != a.Type() || t.NumOut() != 1 || t.Out(0).Kind() != reflect.Bool {
+		return false, false
+	}
+
+	return method.Call([]reflect.Value{b})[0].Bool(), true
+}
+
+func (e *equaler) equalFloats(a, b float64) bool {
+	return a == b || math.Abs(a-b) <= e.options.FloatTolerance
+}
@@ -0,0 +1,230 @@
+package reflectme
+
+import (
+	"reflect"
+	"testing"
+	"time"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TestEqualItem struct {
+	ID    int
+	Price float64
+}
+
+type TestEqualStruct struct {
+	Name      string
+	Active    bool
+	Count     uint
+	Ratio     float32
+	Complex   complex128
+	CreatedAt time.Time
+	Items     []TestEqualItem
+	Tags      []string
+	Labels    map[string]string
+	Matrix    [2]int
+	Next      *TestEqualStruct
+	Any       interface{}
+	Func      func()
+	Chan      chan int
+	Pointer   unsafe.Pointer
+	secret    string
+}
+
+func TestEqual_like_deep_equal(t *testing.T) {
+	ch := make(chan int)
+	a := TestEqualStruct{
+		Name:    "name",
+		Active:  true,
+		Count:   1,
+		Ratio:   0.5,
+		Complex: 1 + 2i,
+		Items:   []TestEqualItem{{ID: 1, Price: 1.5}},
+		Tags:    []string{"a", "b"},
+		Labels:  map[string]string{"env": "dev"},
+		Matrix:  [2]int{1, 2},
+		Next:    &TestEqualStruct{Name: "next"},
+		Any:     1,
+		Chan:    ch,
+		secret:  "secret",
+	}
+	b := a
+	b.Items = []TestEqualItem{{ID: 1, Price: 1.5}}
+	b.Next = &TestEqualStruct{Name: "next"}
+
+	assert.True(t, Equal(a, b, EqualOptions{}))
+	assert.True(t, Equal(&a, &b, EqualOptions{}))
+	assert.True(t, Equal(nil, nil, EqualOptions{}))
+
+	cases := map[string]func(s *TestEqualStruct){
+		"Name":    func(s *TestEqualStruct) { s.Name = "other" },
+		"Active":  func(s *TestEqualStruct) { s.Active = false },
+		"Count":   func(s *TestEqualStruct) { s.Count = 2 },
+		"Ratio":   func(s *TestEqualStruct) { s.Ratio = 0.6 },
+		"Complex": func(s *TestEqualStruct) { s.Complex = 1 + 3i },
+		"Items":   func(s *TestEqualStruct) { s.Items = append(s.Items, TestEqualItem{}) },
+		"Tags":    func(s *TestEqualStruct) { s.Tags = nil },
+		"Labels":  func(s *TestEqualStruct) { s.Labels = map[string]string{"team": "dev"} },
+		"Labels2": func(s *TestEqualStruct) { s.Labels = map[string]string{"env": "dev", "team": "dev"} },
+		"Labels3": func(s *TestEqualStruct) { s.Labels = nil },
+		"Matrix":  func(s *TestEqualStruct) { s.Matrix[1] = 3 },
+		"Next":    func(s *TestEqualStruct) { s.Next = nil },
+		"Next2":   func(s *TestEqualStruct) { s.Next = &TestEqualStruct{Name: "other"} },
+		"Any":     func(s *TestEqualStruct) { s.Any = "1" },
+		"Any2":    func(s *TestEqualStruct) { s.Any = nil },
+		"Func":    func(s *TestEqualStruct) { s.Func = func() {} },
+		"Chan":    func(s *TestEqualStruct) { s.Chan = make(chan int) },
+		"Pointer": func(s *TestEqualStruct) { s.Pointer = unsafe.Pointer(s) },
+		"secret":  func(s *TestEqualStruct) { s.secret = "other" },
+	}
+	for name, change := range cases {
+		c := b
+		change(&c)
+		assert.False(t, Equal(a, c, EqualOptions{}), name)
+		assert.Equal(t, reflect.DeepEqual(a, c), Equal(a, c, EqualOptions{}), name)
+	}
+
+	assert.False(t, Equal(a, &b, EqualOptions{}))
+	assert.False(t, Equal(a, nil, EqualOptions{}))
+}
+
+func TestEqual_on_cycles(t *testing.T) {
+	a := &TestEqualStruct{Name: "a"}
+	a.Next = a
+	b := &TestEqualStruct{Name: "a"}
+	b.Next = b
+
+	assert.True(t, Equal(a, b, EqualOptions{}))
+	assert.True(t, Equal(a, a, EqualOptions{}))
+
+	b.Next = &TestEqualStruct{Name: "b", Next: b}
+	assert.False(t, Equal(a, b, EqualOptions{}))
+}
+
+func TestEqual_on_map_cycles(t *testing.T) {
+	a := map[string]interface{}{"name": "a"}
+	a["self"] = a
+	b := map[string]interface{}{"name": "a"}
+	b["self"] = b
+
+	assert.True(t, Equal(a, b, EqualOptions{}))
+	assert.Equal(t, reflect.DeepEqual(a, b), Equal(a, b, EqualOptions{}))
+
+	b["self"] = map[string]interface{}{"name": "b", "self": b}
+	assert.False(t, Equal(a, b, EqualOptions{}))
+}
+
+func TestEqual_on_slice_cycles(t *testing.T) {
+	a := []interface{}{1, nil}
+	a[1] = a
+	b := []interface{}{1, nil}
+	b[1] = b
+
+	assert.True(t, Equal(a, b, EqualOptions{}))
+	assert.True(t, Equal(a, b, EqualOptions{IgnoreOrder: true}))
+
+	b[1] = []interface{}{2, b}
+	assert.False(t, Equal(a, b, EqualOptions{}))
+}
+
+func TestEqual_with_options(t *testing.T) {
+	a := TestEqualStruct{
+		Name:      "name",
+		Ratio:     0.5,
+		CreatedAt: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		Items:     []TestEqualItem{{ID: 1, Price: 1.5}, {ID: 2, Price: 2}},
+		Labels:    map[string]string{"env": "dev", "team": "core"},
+		Next:      &TestEqualStruct{Name: "next"},
+		secret:    "secret",
+	}
+	b := TestEqualStruct{
+		Name:      "other",
+		Ratio:     0.5001,
+		CreatedAt: a.CreatedAt.In(time.FixedZone("UTC+1", 3600)),
+		Items:     []TestEqualItem{{ID: 20, Price: 2}, {ID: 10, Price: 1.5}},
+		Tags:      []string{},
+		Labels:    map[string]string{"env": "prod", "team": "core"},
+		Next:      &TestEqualStruct{Name: "other"},
+		secret:    "other",
+	}
+
+	options := EqualOptions{
+		IgnorePaths:      []string{"Name", "Items[*].ID", "Labels.env", "Next.Name"},
+		IgnoreUnexported: true,
+		NilEqualsEmpty:   true,
+		FloatTolerance:   0.001,
+		IgnoreOrder:      true,
+		Comparers: map[reflect.Type]func(a, b interface{}) bool{
+			reflect.TypeOf(time.Time{}): func(a, b interface{}) bool {
+				return a.(time.Time).Equal(b.(time.Time))
+			},
+		},
+	}
+	assert.True(t, Equal(a, b, options))
+	assert.False(t, Equal(a, b, EqualOptions{}))
+
+	b.Items[0].Price = 3
+	assert.False(t, Equal(a, b, options))
+	b.Items[0].Price = 2
+
+	b.Labels["team"] = "other"
+	assert.False(t, Equal(a, b, options))
+	b.Labels["team"] = "core"
+
+	options.IgnoreOrder = false
+	assert.False(t, Equal(a, b, options))
+	options.IgnorePaths = []string{"*"}
+	assert.True(t, Equal(a, b, options))
+
+	assert.True(t, Equal(map[string]int(nil), map[string]int{}, EqualOptions{NilEqualsEmpty: true}))
+	assert.False(t, Equal(map[string]int(nil), map[string]int{}, EqualOptions{}))
+	assert.False(t, Equal([]int{1, 1}, []int{1, 2}, EqualOptions{IgnoreOrder: true}))
+}
+
+func TestEqual_on_unexported_fields_of_known_types(t *testing.T) {
+	type wrapper struct {
+		When time.Time
+		O    Optional[int]
+		Opt  Optional[TestEqualItem]
+	}
+
+	options := EqualOptions{IgnoreUnexported: true}
+	date := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.False(t, Equal(wrapper{When: date}, wrapper{When: date.Add(time.Hour)}, options))
+	assert.True(t, Equal(wrapper{When: date}, wrapper{When: date.In(time.FixedZone("UTC+1", 3600))}, options))
+	assert.False(t, Equal(wrapper{When: date}, wrapper{When: date.In(time.FixedZone("UTC+1", 3600))}, EqualOptions{}))
+
+	assert.False(t, Equal(wrapper{O: Some(1)}, wrapper{O: Some(2)}, options))
+	assert.False(t, Equal(wrapper{O: Some(0)}, wrapper{O: Null[int]()}, options))
+	assert.False(t, Equal(wrapper{}, wrapper{O: Null[int]()}, options))
+	assert.True(t, Equal(wrapper{O: Some(1)}, wrapper{O: Some(1)}, options))
+	assert.True(t, Equal(wrapper{O: Some(1)}, wrapper{O: Some(1)}, EqualOptions{}))
+	assert.False(t, Equal(wrapper{O: Some(1)}, wrapper{O: Some(2)}, EqualOptions{}))
+
+	// Options apply to the Optional values
+	a := wrapper{Opt: Some(TestEqualItem{ID: 1, Price: 1})}
+	b := wrapper{Opt: Some(TestEqualItem{ID: 2, Price: 1})}
+	assert.True(t, Equal(a, b, EqualOptions{IgnorePaths: []string{"Opt.ID"}}))
+
+	// Equal methods need the same signature
+	type other struct{ value int }
+	assert.False(t, Equal(TestEqualMethod{1}, TestEqualMethod{2}, options))
+	assert.True(t, Equal(other{1}, other{2}, options))
+	assert.True(t, Equal(TestEqualBadMethod{1}, TestEqualBadMethod{2}, options))
+}
+
+type TestEqualMethod struct{ value int }
+
+func (m TestEqualMethod) Equal(other TestEqualMethod) bool { return m.value == other.value }
+
+type TestEqualBadMethod struct{ value int }
+
+func (m TestEqualBadMethod) Equal(other interface{}) bool { return false }
+
+func TestEqual_on_unexported_optional_fields(t *testing.T) {
+	type wrapper struct{ o Optional[int] }
+	assert.False(t, Equal(wrapper{Some(1)}, wrapper{Some(2)}, EqualOptions{}))
+	assert.True(t, Equal(wrapper{Some(1)}, wrapper{Some(1)}, EqualOptions{}))
+}
@@ -0,0 +1,113 @@
+package reflectme
+
+import (
+	"reflect"
+	"strings"
+)
+
+type (
+	// FieldsOptions are options for FieldsWithOptions function
+	FieldsOptions struct {
+		TraverseOptions
+		// LeavesOnly skips the fields whose nested fields are listed
+		LeavesOnly bool
+		// IncludeUnexported lists and walks through unexported fields
+		IncludeUnexported bool
+		// Kinds lists only the fields of these kinds when not empty
+		Kinds []reflect.Kind
+		// Types lists only the fields of these types when not empty
+		Types []reflect.Type
+		// TagFilter lists only the fields whose tag it accepts when not nil
+		TagFilter func(tag reflect.StructTag) bool
+	}
+
+	// FieldDescriptor describes a field listed by FieldsWithOptions
+	FieldDescriptor struct {
+		// Path is the dotted field name as accepted by GetField
+		Path string
+		// Field is the struct field
+		Field reflect.StructField
+		// Kind is the field type kind
+		Kind reflect.Kind
+		// Value is the field current value. It's invalid when the field
+		// is walked only by type and can't be used with Interface when
+		// the field is unexported.
+		Value reflect.Value
+	}
+)
+
+var (
+	// DefaultFieldsOptions are the default options for FieldsWithOptions function
+	DefaultFieldsOptions = FieldsOptions{}
+)
+
+// FieldsWithOptions returns the struct fields descriptors list with
+// FieldsOptions. obj can whether be a structure or pointer to structure.
+// Filters only select the listed fields, nested fields are walked anyway.
+func FieldsWithOptions(obj interface{}, options FieldsOptions) ([]FieldDescriptor, error) {
+	var all []FieldDescriptor
+	w := newWalker(options.TraverseOptions, func(path string, field reflect.StructField, value reflect.Value) {
+		all = append(all, FieldDescriptor{
+			Path:  path,
+			Field: field,
+			Kind:  field.Type.Kind(),
+			Value: value,
+		})
+	})
+	w.unexported = options.IncludeUnexported
+	if err := w.traverse(obj); err != nil {
+		return nil, err
+	}
+
+	var fields []FieldDescriptor
+	for i, field := range all {
+		// Nested fields are listed right after their parent
+		hasNested := i+1 < len(all) && isNestedPath(field.Path, all[i+1].Path)
+		if options.LeavesOnly && hasNested {
+			continue
+		}
+		if options.accepts(field) {
+			fields = append(fields, field)
+		}
+	}
+
+	return fields, nil
+}
+
+func (o FieldsOptions) accepts(field FieldDescriptor) bool {
+	if len(o.Kinds) > 0 && !hasKind(o.Kinds, field.Kind) {
+		return false
+	}
+	if len(o.Types) > 0 && !hasType(o.Types, field.Field.Type) {
+		return false
+	}
+	if o.TagFilter != nil && !o.TagFilter(field.Field.Tag) {
+		return false
+	}
+
+	return true
+}
+
+func isNestedPath(parent, path string) bool {
+	return strings.HasPrefix(path, parent+".") || strings.HasPrefix(path, parent+"[")
+}
+
+func hasKind(kinds []reflect.Kind, kind reflect.Kind) bool {
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasType(types []reflect.Type, t reflect.Type) bool {
+	for _, typ := range types {
+		if typ == t {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,102 @@
+package reflectme
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TestFieldsOptionsStruct struct {
+	unexported NestedStruct
+	Name       string `form:"name" json:"name"`
+	Age        int    `form:"age"`
+	CreatedAt  time.Time
+	Address    *NestedStruct `form:"address"`
+	Nested     TestNestedStruct
+}
+
+func fieldsPaths(fields []FieldDescriptor) []string {
+	paths := make([]string, len(fields))
+	for i, field := range fields {
+		paths[i] = field.Path
+	}
+	return paths
+}
+
+func TestFieldsWithOptions_default(t *testing.T) {
+	dummyStruct := TestFieldsOptionsStruct{
+		Name:    "test",
+		Address: &NestedStruct{Dummy: "street"},
+	}
+
+	fields, err := FieldsWithOptions(&dummyStruct, DefaultFieldsOptions)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"Name", "Age", "CreatedAt", "Address", "Address.Dummy", "Address.Yummy",
+		"Nested", "Nested.Dummy", "Nested.Yummy", "Nested.Nested", "Nested.Nested.Dummy", "Nested.Nested.Yummy",
+	}, fieldsPaths(fields))
+
+	assert.Equal(t, "Name", fields[0].Field.Name)
+	assert.Equal(t, reflect.String, fields[0].Kind)
+	assert.Equal(t, "test", fields[0].Value.Interface())
+	assert.Equal(t, "street", fields[4].Value.Interface())
+}
+
+func TestFieldsWithOptions_leaves_only_with_max_depth(t *testing.T) {
+	options := FieldsOptions{
+		TraverseOptions: TraverseOptions{MaxDepth: 2, ExpandNilPointers: true},
+		LeavesOnly:      true,
+	}
+
+	fields, err := FieldsWithOptions(TestFieldsOptionsStruct{}, options)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"Name", "Age", "CreatedAt", "Address.Dummy", "Address.Yummy",
+		"Nested.Dummy", "Nested.Yummy", "Nested.Nested",
+	}, fieldsPaths(fields))
+	assert.False(t, fields[3].Value.IsValid())
+}
+
+func TestFieldsWithOptions_including_unexported(t *testing.T) {
+	options := FieldsOptions{
+		TraverseOptions:   TraverseOptions{MaxDepth: 1},
+		IncludeUnexported: true,
+	}
+
+	fields, err := FieldsWithOptions(TestFieldsOptionsStruct{}, options)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"unexported", "Name", "Age", "CreatedAt", "Address", "Nested"}, fieldsPaths(fields))
+	assert.False(t, fields[0].Value.CanInterface())
+}
+
+func TestFieldsWithOptions_with_filters(t *testing.T) {
+	fields, err := FieldsWithOptions(TestFieldsOptionsStruct{}, FieldsOptions{
+		Kinds: []reflect.Kind{reflect.String, reflect.Int},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"Name", "Age", "Nested.Dummy", "Nested.Yummy", "Nested.Nested.Dummy", "Nested.Nested.Yummy",
+	}, fieldsPaths(fields))
+
+	fields, err = FieldsWithOptions(TestFieldsOptionsStruct{}, FieldsOptions{
+		Types: []reflect.Type{reflect.TypeOf(time.Time{}), reflect.TypeOf(NestedStruct{})},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"CreatedAt", "Nested.Nested"}, fieldsPaths(fields))
+
+	fields, err = FieldsWithOptions(TestFieldsOptionsStruct{}, FieldsOptions{
+		TagFilter: func(tag reflect.StructTag) bool {
+			_, ok := tag.Lookup("form")
+			return ok
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Name", "Age", "Address"}, fieldsPaths(fields))
+}
+
+func TestFieldsWithOptions_on_non_struct(t *testing.T) {
+	_, err := FieldsWithOptions("abc 123", DefaultFieldsOptions)
+	assert.Error(t, err)
+}
@@ -0,0 +1,585 @@
+package reflectme
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	// FakeGenerator returns a pseudo-random value from r
+	FakeGenerator func(r *rand.Rand) interface{}
+
+	// FillOptions are options for fill function
+	FillOptions struct {
+		// Seed seeds the pseudo-random data, so equal seeds fill equal
+		// values
+		Seed int64
+		// MinItems is the minimum length of the filled slices and maps
+		MinItems int
+		// MaxItems is the maximum length of the filled slices and maps
+		MaxItems int
+		// MaxDepth is the maximum struct nesting filled, 0 means no
+		// limit. Deeper structs are left zero.
+		MaxDepth int
+		// Generators fill the values of their types instead of the
+		// default data
+		Generators map[reflect.Type]FakeGenerator
+		// Fakers add or replace the fake tag generators by name
+		Fakers map[string]FakeGenerator
+	}
+
+	filler struct {
+		options FillOptions
+		rand    *rand.Rand
+		types   map[reflect.Type]bool
+		// arbitrary fills edge cases, like zero, nil and bounds, instead
+		// of realistic data
+		arbitrary bool
+	}
+
+	// fillConstraint are the fake and validate tag constraints of a value
+	fillConstraint struct {
+		fake     string
+		schema   JSONSchema
+		required bool
+	}
+)
+
+// requiredRetries is the number of times a required value is filled
+// again while it's zero
+const requiredRetries = 10
+
+var (
+	// DefaultFillOptions are the default options for fill function
+	DefaultFillOptions = FillOptions{MinItems: 1, MaxItems: 3}
+
+	fakeFirstNames = []string{"Alice", "Bob", "Carol", "Dave", "Eve", "Frank", "Grace", "Heidi"}
+	fakeLastNames  = []string{"Smith", "Jones", "Brown", "Taylor", "Wilson", "Evans", "Clark", "Lopez"}
+
+	fakers = map[string]FakeGenerator{
+		"name": func(r *rand.Rand) interface{} {
+			return fakeFirstNames[r.Intn(len(fakeFirstNames))] + " " + fakeLastNames[r.Intn(len(fakeLastNames))]
+		},
+		"email": func(r *rand.Rand) interface{} {
+			first := fakeFirstNames[r.Intn(len(fakeFirstNames))]
+			last := fakeLastNames[r.Intn(len(fakeLastNames))]
+			return fmt.Sprintf("%s.%s%d@example.com", strings.ToLower(first), strings.ToLower(last), r.Intn(100))
+		},
+		"uuid": func(r *rand.Rand) interface{} {
+			var b [16]byte
+			r.Read(b[:])
+			b[6] = b[6]&0x0f | 0x40
+			b[8] = b[8]&0x3f | 0x80
+			return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+		},
+		"ipv4": func(r *rand.Rand) interface{} {
+			return fmt.Sprintf("%d.%d.%d.%d", 1+r.Intn(254), r.Intn(256), r.Intn(256), 1+r.Intn(254))
+		},
+		"ipv6": func(r *rand.Rand) interface{} {
+			b := make(net.IP, net.IPv6len)
+			r.Read(b)
+			return b.String()
+		},
+		"hostname": func(r *rand.Rand) interface{} {
+			return fakeWord(r) + ".example.com"
+		},
+		"url": func(r *rand.Rand) interface{} {
+			return "https://" + fakeWord(r) + ".example.com/" + fakeWord(r)
+		},
+	}
+
+	// arbitraryRunes are the runes of the arbitrary strings, with
+	// whitespace, punctuation and multi-byte ones
+	arbitraryRunes = []rune("abcxyzABCXYZ0189 _-.,@/\\\"'\t\néñü日本🙂")
+)
+
+// Fill fills every exported field of the struct pointed by obj, walking
+// through nested structs, pointers, slices, arrays, maps and Optional
+// values, with pseudo-random data from FillOptions.Seed. The values are
+// set like SetField does, so tests can override the paths they care
+// about afterwards. Struct types already being filled in the current
+// path, interfaces, funcs and channels are left zero.
+//
+// Fields can choose their data with the fake tag, which also applies to
+// the elements of their collections:
+//   - `fake:"name"`, `fake:"email"`, `fake:"uuid"`, `fake:"ipv4"`,
+//     `fake:"ipv6"`, `fake:"hostname"` and `fake:"url"` fill strings
+//   - `fake:"int:1-10"` fills a number between 1 and 10
+//   - `fake:"oneof:a,b"` fills one of the comma separated values
+//   - `fake:"-"` leaves the field zero
+//
+// The validate tag constraints GenerateJSONSchema reads are respected
+// too, eg `validate:"required,min=1,max=5"` or `validate:"email"`.
+// FillOptions.Fakers add other fake tag names and Generators fill the
+// values of a type.
+func Fill(obj interface{}, options FillOptions) error {
+	if obj == nil || !isPointer(obj) || structType(reflect.TypeOf(obj)) == nil || reflect.ValueOf(obj).IsNil() {
+		return errors.New("Cannot use Fill on a non-struct pointer")
+	}
+
+	f := &filler{
+		options: options,
+		rand:    rand.New(rand.NewSource(options.Seed)),
+		types:   make(map[reflect.Type]bool),
+	}
+
+	return f.fill(reflect.ValueOf(obj).Elem(), "", fillConstraint{}, 1)
+}
+
+// fill fills v, which is the path value, respecting its constraint. The
+// required values are filled again while they are zero.
+func (f *filler) fill(v reflect.Value, path string, c fillConstraint, depth int) error {
+	for i := 0; ; i++ {
+		if err := f.fillValue(v, path, c, depth); err != nil {
+			return err
+		}
+		if !c.required || !v.IsZero() || i == requiredRetries {
+			return nil
+		}
+	}
+}
+
+// fillValue fills v with the fake generator, enum or format of its
+// constraint, if any, or by its type
+func (f *filler) fillValue(v reflect.Value, path string, c fillConstraint, depth int) error {
+	t := v.Type()
+	leaf := isFillLeafType(t)
+	switch {
+	case leaf && c.fake != "":
+		return f.fillFake(v, path, c.fake)
+	case f.options.Generators[t] != nil:
+		return setFilledValue(v, path, f.options.Generators[t](f.rand))
+	case leaf && len(c.schema.Enum) > 0:
+		return setFilledValue(v, path, c.schema.Enum[f.rand.Intn(len(c.schema.Enum))])
+	case leaf && c.schema.Format != "":
+		if c.schema.Format == "uri" {
+			return f.fillFake(v, path, "url")
+		}
+		return f.fillFake(v, path, c.schema.Format)
+	}
+
+	switch {
+	case t == timeType:
+		if f.arbitrary && f.rand.Intn(10) == 0 {
+			v.Set(reflect.Zero(t))
+			return nil
+		}
+		date := time.Date(2000+f.rand.Intn(30), time.January, 1, 0, 0, 0, 0, time.UTC)
+		v.Set(reflect.ValueOf(date.Add(time.Duration(f.rand.Int63n(int64(365 * 24 * time.Hour))))))
+	case isOptionalType(t):
+		if f.arbitrary && !c.required {
+			switch f.rand.Intn(4) {
+			case 0:
+				v.Set(reflect.Zero(t))
+				return nil
+			case 1:
+				return setOptionalField(v, nil)
+			}
+		}
+		elem := reflect.New(v.Interface().(optional).optionalElem()).Elem()
+		if err := f.fill(elem, path, c, depth); err != nil {
+			return err
+		}
+		return setOptionalField(v, elem.Interface())
+	case t.Kind() == reflect.Struct:
+		if !f.fills(t, depth) {
+			return nil
+		}
+		return f.fillStruct(v, path, depth)
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		v.SetBool(f.rand.Intn(2) == 1)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		lo, hi := intRange(t, c.schema)
+		if lo > hi {
+			return fmt.Errorf("Cannot fill %s: no %v value matches its constraints", path, t)
+		}
+		v.SetInt(f.intIn(lo, hi))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		lo, hi := intRange(t, c.schema)
+		if lo > hi {
+			return fmt.Errorf("Cannot fill %s: no %v value matches its constraints", path, t)
+		}
+		v.SetUint(uint64(f.intIn(lo, hi)))
+	case reflect.Float32, reflect.Float64:
+		lo, hi := floatRange(t, c.schema)
+		if lo > hi {
+			return fmt.Errorf("Cannot fill %s: no %v value matches its constraints", path, t)
+		}
+		v.SetFloat(f.floatIn(lo, hi))
+	case reflect.Complex64, reflect.Complex128:
+		v.SetComplex(complex(float64(1+f.rand.Intn(100)), float64(1+f.rand.Intn(100))))
+	case reflect.String:
+		v.SetString(f.fillString(c.schema))
+	case reflect.Ptr:
+		if !f.fills(t.Elem(), depth) || (f.arbitrary && !c.required && f.rand.Intn(5) == 0) {
+			v.Set(reflect.Zero(t))
+			return nil
+		}
+		elem := reflect.New(t.Elem())
+		if err := f.fill(elem.Elem(), path, c, depth); err != nil {
+			return err
+		}
+		v.Set(elem)
+	case reflect.Slice, reflect.Array:
+		if !f.fills(t.Elem(), depth) {
+			return nil
+		}
+		if t.Kind() == reflect.Slice {
+			n := f.items(c.schema)
+			v.Set(reflect.MakeSlice(t, n, n))
+		}
+		for i := 0; i < v.Len(); i++ {
+			if err := f.fill(v.Index(i), fmt.Sprintf("%s[%d]", path, i), fillConstraint{fake: c.fake}, depth); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if !f.fills(t.Elem(), depth) {
+			return nil
+		}
+		m := reflect.MakeMap(t)
+		for i, n := 0, f.items(c.schema); i < n; i++ {
+			key := reflect.New(t.Key()).Elem()
+			if err := f.fill(key, path, fillConstraint{}, depth); err != nil {
+				return err
+			}
+			elem := reflect.New(t.Elem()).Elem()
+			if err := f.fill(elem, fmt.Sprintf("%s[%v]", path, key), fillConstraint{fake: c.fake}, depth); err != nil {
+				return err
+			}
+			m.SetMapIndex(key, elem)
+		}
+		v.Set(m)
+	}
+
+	return nil
+}
+
+func (f *filler) fillStruct(v reflect.Value, path string, depth int) error {
+	t := v.Type()
+	f.types[t] = true
+	defer delete(f.types, t)
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !isExportableField(field) {
+			continue
+		}
+
+		fieldPath := field.Name
+		if len(path) > 0 {
+			fieldPath = path + "." + field.Name
+		}
+		c, err := fieldConstraint(field, fieldPath)
+		if err != nil {
+			return err
+		}
+		if c.fake == "-" {
+			continue
+		}
+		if err := f.fill(v.Field(i), fieldPath, c, depth+1); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fieldConstraint returns the fake and validate tag constraints of the
+// field, which apply to the Optional and pointer values
+func fieldConstraint(field reflect.StructField, path string) (fillConstraint, error) {
+	c := fillConstraint{fake: field.Tag.Get("fake")}
+	t := field.Type
+	if isOptionalType(t) {
+		t = reflect.Zero(t).Interface().(optional).optionalElem()
+	}
+
+	required, err := applyValidateTag(&c.schema, t, field.Tag.Get("validate"))
+	if err != nil {
+		return c, fmt.Errorf("Invalid validate tag on field %s: %v", path, err)
+	}
+	c.required = required
+
+	return c, nil
+}
+
+// fills indicates if the values of type t are filled at depth, which
+// isn't the case for the struct types deeper than MaxDepth or already
+// being filled
+func (f *filler) fills(t reflect.Type, depth int) bool {
+	st := structType(indirectType(t))
+	if st == nil || st == timeType || isOptionalType(st) {
+		return true
+	}
+
+	return !f.types[st] && (f.options.MaxDepth <= 0 || depth <= f.options.MaxDepth)
+}
+
+// fillFake fills v with the fake tag generator
+func (f *filler) fillFake(v reflect.Value, path, fake string) error {
+	name, args, _ := strings.Cut(fake, ":")
+	if generate, ok := f.options.Fakers[name]; ok {
+		return setFilledValue(v, path, generate(f.rand))
+	}
+	if generate, ok := fakers[name]; ok {
+		return setFilledValue(v, path, generate(f.rand))
+	}
+
+	switch name {
+	case "int":
+		var min, max int64
+		if n, _ := fmt.Sscanf(args, "%d-%d", &min, &max); n != 2 || min > max {
+			return fmt.Errorf("Invalid fake range: %s in field %s", args, path)
+		}
+		return setFilledValue(v, path, min+f.rand.Int63n(max-min+1))
+	case "oneof":
+		options := strings.Split(args, ",")
+		return setFilledValue(v, path, options[f.rand.Intn(len(options))])
+	}
+
+	return fmt.Errorf("Invalid fake generator: %s in field %s", name, path)
+}
+
+// items returns a slice or map length between the schema, or options,
+// minimum and maximum items
+func (f *filler) items(schema JSONSchema) int {
+	lo, hi := f.options.MinItems, f.options.MaxItems
+	if schema.MinItems != nil {
+		lo = *schema.MinItems
+	}
+	if schema.MaxItems != nil {
+		hi = *schema.MaxItems
+		lo = min(lo, hi)
+	}
+
+	n := lo
+	if hi > n {
+		n += f.rand.Intn(hi - n + 1)
+	}
+
+	return n
+}
+
+// intIn returns a number between lo and hi, preferring small positive
+// ones, or small ones and the bounds when filling arbitrary values
+func (f *filler) intIn(lo, hi int64) int64 {
+	a, b := max(lo, 1), min(hi, 100)
+	if f.arbitrary {
+		switch f.rand.Intn(10) {
+		case 0:
+			return max(lo, min(hi, 0))
+		case 1:
+			return lo
+		case 2:
+			return hi
+		}
+		a, b = max(lo, -1000), min(hi, 1000)
+	}
+	if a > b {
+		a, b = lo, hi
+	}
+
+	// The fallback ranges are one-sided, but may not fit in int64
+	span := uint64(b) - uint64(a)
+	if span < math.MaxInt64 {
+		return a + f.rand.Int63n(int64(span)+1)
+	}
+
+	return int64(uint64(a) + f.rand.Uint64()%(span+1))
+}
+
+// floatIn returns a number between lo and hi, like intIn does
+func (f *filler) floatIn(lo, hi float64) float64 {
+	a, b := math.Max(lo, 0.01), math.Min(hi, 100)
+	if f.arbitrary {
+		switch f.rand.Intn(10) {
+		case 0:
+			return math.Max(lo, math.Min(hi, 0))
+		case 1:
+			return lo
+		case 2:
+			return hi
+		}
+		a, b = math.Max(lo, -1000), math.Min(hi, 1000)
+	} else if a == 0.01 && b == 100 {
+		return float64(1+f.rand.Intn(10000)) / 100
+	}
+	if a > b {
+		a, b = lo, hi
+	}
+
+	return a + f.rand.Float64()*(b-a)
+}
+
+// fillString returns a fake word, or random runes when filling arbitrary
+// values, with the schema length
+func (f *filler) fillString(schema JSONSchema) string {
+	lo, hi := 0, -1
+	if schema.MinLength != nil {
+		lo = *schema.MinLength
+	}
+	if schema.MaxLength != nil {
+		hi = max(*schema.MaxLength, lo)
+	}
+
+	var runes []rune
+	if f.arbitrary {
+		n := lo + 10
+		if hi >= 0 {
+			n = min(n, hi)
+		}
+		runes = make([]rune, lo+f.rand.Intn(n-lo+1))
+		for i := range runes {
+			runes[i] = arbitraryRunes[f.rand.Intn(len(arbitraryRunes))]
+		}
+	} else {
+		runes = []rune(fakeWord(f.rand))
+		for len(runes) < lo {
+			runes = append(runes, []rune(fakeWord(f.rand))...)
+		}
+	}
+	if hi >= 0 && len(runes) > hi {
+		runes = runes[:hi]
+	}
+
+	return string(runes)
+}
+
+// setFilledValue sets v to value like SetField does, parsing strings
+// for the other basic types
+func setFilledValue(v reflect.Value, path string, value interface{}) error {
+	if s, ok := value.(string); ok && v.Kind() != reflect.String && v.Kind() != reflect.Interface {
+		parsed, err := parseFilledValue(s, v.Type())
+		if err != nil {
+			return fmt.Errorf("Cannot fill %s: %v", path, err)
+		}
+		value = parsed
+	}
+
+	converted, err := convertValue(value, v.Type())
+	if err != nil {
+		return fmt.Errorf("Cannot fill %s: %v", path, err)
+	}
+	v.Set(converted)
+
+	return nil
+}
+
+func parseFilledValue(s string, t reflect.Type) (interface{}, error) {
+	var value interface{}
+	var err error
+	switch {
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Int64:
+		value, err = strconv.ParseInt(s, 10, t.Bits())
+	case t.Kind() >= reflect.Uint && t.Kind() <= reflect.Uintptr:
+		value, err = strconv.ParseUint(s, 10, t.Bits())
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		value, err = strconv.ParseFloat(s, t.Bits())
+	case t.Kind() == reflect.Bool:
+		value, err = strconv.ParseBool(s)
+	default:
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Invalid %v value: %s", t, s)
+	}
+
+	return value, nil
+}
+
+// intRange returns the range of the t integers, capped to the int64
+// ones, that match the schema bounds
+func intRange(t reflect.Type, schema JSONSchema) (int64, int64) {
+	lo, hi := int64(math.MinInt64)>>(64-t.Bits()), int64(math.MaxInt64)>>(64-t.Bits())
+	if t.Kind() >= reflect.Uint && t.Kind() <= reflect.Uintptr {
+		lo, hi = 0, int64(min(uint64(math.MaxUint64)>>(64-t.Bits()), math.MaxInt64))
+	}
+
+	if schema.Minimum != nil {
+		lo = max(lo, floatToInt(math.Ceil(*schema.Minimum)))
+	}
+	if schema.ExclusiveMinimum != nil {
+		lo = max(lo, floatToInt(math.Floor(*schema.ExclusiveMinimum)+1))
+	}
+	if schema.Maximum != nil {
+		hi = min(hi, floatToInt(math.Floor(*schema.Maximum)))
+	}
+	if schema.ExclusiveMaximum != nil {
+		hi = min(hi, floatToInt(math.Ceil(*schema.ExclusiveMaximum)-1))
+	}
+
+	return lo, hi
+}
+
+// floatRange returns the range of the t floats that match the schema
+// bounds
+func floatRange(t reflect.Type, schema JSONSchema) (float64, float64) {
+	lo, hi := -math.MaxFloat64, math.MaxFloat64
+	if t.Bits() == 32 {
+		lo, hi = -math.MaxFloat32, math.MaxFloat32
+	}
+
+	if schema.Minimum != nil {
+		lo = math.Max(lo, *schema.Minimum)
+	}
+	if schema.ExclusiveMinimum != nil {
+		lo = math.Max(lo, nextFloat(*schema.ExclusiveMinimum, t.Bits(), math.Inf(1)))
+	}
+	if schema.Maximum != nil {
+		hi = math.Min(hi, *schema.Maximum)
+	}
+	if schema.ExclusiveMaximum != nil {
+		hi = math.Min(hi, nextFloat(*schema.ExclusiveMaximum, t.Bits(), math.Inf(-1)))
+	}
+
+	return lo, hi
+}
+
+// nextFloat returns the next float of the bits size after f towards to
+func nextFloat(f float64, bits int, to float64) float64 {
+	if bits == 32 {
+		return float64(math.Nextafter32(float32(f), float32(to)))
+	}
+
+	return math.Nextafter(f, to)
+}
+
+// floatToInt converts f to int64, saturating at the int64 bounds
+func floatToInt(f float64) int64 {
+	switch {
+	case f >= math.MaxInt64:
+		return math.MaxInt64
+	case f <= math.MinInt64:
+		return math.MinInt64
+	}
+
+	return int64(f)
+}
+
+// isFillLeafType indicates if the fake, enum and format constraints
+// apply to t values instead of their elements
+func isFillLeafType(t reflect.Type) bool {
+	return !isCollectionKind(t.Kind()) && t.Kind() != reflect.Ptr && !isOptionalType(t)
+}
+
+// fakeWord returns a pronounceable lowercase word
+func fakeWord(r *rand.Rand) string {
+	const consonants, vowels = "bcdfghjklmnprstvz", "aeiou"
+	var b strings.Builder
+	for i, n := 0, 2+r.Intn(3); i < n; i++ {
+		b.WriteByte(consonants[r.Intn(len(consonants))])
+		b.WriteByte(vowels[r.Intn(len(vowels))])
+	}
+
+	return b.String()
+}
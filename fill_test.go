@@ -0,0 +1,256 @@
+package reflectme
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TestFillStatus string
+
+type TestFillAddress struct {
+	City string `fake:"oneof:Lisbon,Porto"`
+	Zip  int    `fake:"int:1000-9999"`
+}
+
+type TestFillStruct struct {
+	ID        string         `fake:"uuid"`
+	Name      string         `fake:"name"`
+	Email     string         `fake:"email"`
+	Age       uint8          `fake:"int:18-65"`
+	Offset    int            `fake:"int:-5--1"`
+	Status    TestFillStatus `fake:"oneof:active,blocked"`
+	Level     int            `fake:"oneof:1,2,3"`
+	Code      string         `fake:"code"`
+	Word      string
+	Count     int64
+	Size      uint
+	Ratio     float32
+	Active    bool
+	Complex   complex64
+	CreatedAt time.Time
+	Address   TestFillAddress
+	Billing   *TestFillAddress
+	Emails    []string `fake:"email"`
+	Scores    map[string]float64
+	Matrix    [2]int8
+	Nickname  Optional[string] `fake:"name"`
+	Parent    *TestFillStruct
+	Children  []TestFillStruct
+	Any       interface{}
+	Func      func()
+	Skipped   string `fake:"-"`
+	secret    string
+}
+
+func TestFill(t *testing.T) {
+	var obj TestFillStruct
+	options := FillOptions{
+		Seed:     1,
+		MinItems: 2,
+		MaxItems: 2,
+		Fakers: map[string]FakeGenerator{
+			"code": func(r *rand.Rand) interface{} { return "CODE" },
+		},
+	}
+	assert.NoError(t, Fill(&obj, options))
+
+	assert.Regexp(t, regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`), obj.ID)
+	assert.Regexp(t, regexp.MustCompile(`^[A-Z][a-z]+ [A-Z][a-z]+$`), obj.Name)
+	assert.Regexp(t, regexp.MustCompile(`^[a-z]+\.[a-z]+\d+@example\.com$`), obj.Email)
+	assert.True(t, obj.Age >= 18 && obj.Age <= 65)
+	assert.True(t, obj.Offset >= -5 && obj.Offset <= -1)
+	assert.Contains(t, []TestFillStatus{"active", "blocked"}, obj.Status)
+	assert.Contains(t, []int{1, 2, 3}, obj.Level)
+	assert.Equal(t, "CODE", obj.Code)
+	assert.Regexp(t, regexp.MustCompile(`^[a-z]{4,8}$`), obj.Word)
+	assert.NotZero(t, obj.Count)
+	assert.NotZero(t, obj.Size)
+	assert.NotZero(t, obj.Ratio)
+	assert.NotZero(t, obj.Complex)
+	assert.True(t, obj.CreatedAt.Year() >= 2000 && obj.CreatedAt.Year() < 2031)
+	assert.Contains(t, []string{"Lisbon", "Porto"}, obj.Address.City)
+	assert.True(t, obj.Address.Zip >= 1000 && obj.Address.Zip <= 9999)
+	assert.NotNil(t, obj.Billing)
+	assert.NotZero(t, obj.Billing.Zip)
+	assert.Len(t, obj.Emails, 2)
+	assert.Regexp(t, regexp.MustCompile(`@example\.com$`), obj.Emails[1])
+	assert.Len(t, obj.Scores, 2)
+	assert.NotZero(t, obj.Matrix[1])
+	assert.True(t, obj.Nickname.IsSet())
+
+	// Recursive types are filled once per path
+	assert.Nil(t, obj.Parent)
+	assert.Nil(t, obj.Children)
+	assert.Nil(t, obj.Any)
+	assert.Nil(t, obj.Func)
+	assert.Empty(t, obj.Skipped)
+	assert.Empty(t, obj.secret)
+
+	// Equal seeds fill equal values
+	var other TestFillStruct
+	assert.NoError(t, Fill(&other, options))
+	assert.Equal(t, obj, other)
+
+	options.Seed = 2
+	assert.NoError(t, Fill(&other, options))
+	assert.NotEqual(t, obj.ID, other.ID)
+
+	// Filled values can be overridden like any other
+	assert.NoError(t, SetField(&obj, "Address.City", "Braga"))
+	assert.Equal(t, "Braga", obj.Address.City)
+}
+
+func TestFill_with_options(t *testing.T) {
+	type wrapper struct {
+		Root   TestFillAddress
+		Nested struct {
+			Address   TestFillAddress
+			Addresses map[string]TestFillAddress
+		}
+		Pointers map[TestFillStatus]*TestFillAddress
+		Dates    []time.Time
+		Status   TestFillStatus
+	}
+
+	var obj wrapper
+	date := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	err := Fill(&obj, FillOptions{
+		MinItems: 1,
+		MaxDepth: 2,
+		Generators: map[reflect.Type]FakeGenerator{
+			reflect.TypeOf(time.Time{}):        func(r *rand.Rand) interface{} { return date },
+			reflect.TypeOf(TestFillStatus("")): func(r *rand.Rand) interface{} { return "generated" },
+		},
+	})
+	assert.NoError(t, err)
+	assert.NotZero(t, obj.Root.Zip)
+	assert.Zero(t, obj.Nested)
+	assert.Len(t, obj.Pointers, 1)
+	assert.Equal(t, []time.Time{date}, obj.Dates)
+	assert.Equal(t, TestFillStatus("generated"), obj.Status)
+
+	err = Fill(&obj, FillOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, obj.Dates)
+	assert.Empty(t, obj.Nested.Addresses)
+	assert.NotZero(t, obj.Nested.Address.Zip)
+}
+
+func TestFill_with_validate_tag(t *testing.T) {
+	type constrained struct {
+		IPv4      string           `fake:"ipv4"`
+		IPv6      string           `validate:"ipv6"`
+		Host      string           `validate:"hostname"`
+		URL       string           `validate:"url"`
+		Email     *string          `validate:"required,email"`
+		Role      string           `validate:"oneof=admin user"`
+		Level     uint8            `validate:"oneof=7 9"`
+		Big       int              `validate:"min=1000"`
+		Negative  int64            `validate:"max=0"`
+		Saturated int              `validate:"gte=1e30"`
+		Low       int              `validate:"lte=-1e30"`
+		Small     uint16           `validate:"gt=1,lt=3"`
+		Ratio     float32          `validate:"gt=200,lt=300"`
+		Float     float64          `validate:"gte=0.5,lte=0.75"`
+		Word      string           `validate:"min=12,max=12"`
+		Short     string           `validate:"max=1"`
+		Tags      []string         `validate:"min=4,max=4"`
+		Labels    map[string]int   `validate:"max=0"`
+		Nickname  Optional[string] `validate:"len=3"`
+	}
+
+	var obj constrained
+	assert.NoError(t, Fill(&obj, DefaultFillOptions))
+	assert.Regexp(t, `^\d+\.\d+\.\d+\.\d+$`, obj.IPv4)
+	assert.Contains(t, obj.IPv6, ":")
+	assert.Regexp(t, `^[a-z]+\.example\.com$`, obj.Host)
+	assert.Regexp(t, `^https://[a-z]+\.example\.com/[a-z]+$`, obj.URL)
+	assert.Regexp(t, `@example\.com$`, *obj.Email)
+	assert.Contains(t, []string{"admin", "user"}, obj.Role)
+	assert.Contains(t, []uint8{7, 9}, obj.Level)
+	assert.True(t, obj.Big >= 1000)
+	assert.True(t, obj.Negative <= 0)
+	assert.Equal(t, math.MaxInt64, obj.Saturated)
+	assert.Equal(t, math.MinInt64, obj.Low)
+	assert.Equal(t, uint16(2), obj.Small)
+	assert.True(t, obj.Ratio > 200 && obj.Ratio < 300)
+	assert.True(t, obj.Float >= 0.5 && obj.Float <= 0.75)
+	assert.Len(t, obj.Word, 12)
+	assert.Len(t, obj.Short, 1)
+	assert.Len(t, obj.Tags, 4)
+	assert.Empty(t, obj.Labels)
+	nickname, _ := obj.Nickname.Get()
+	assert.Len(t, nickname, 3)
+
+	// Arbitrary strings respect the maximum length too
+	f := &filler{rand: rand.New(rand.NewSource(1)), arbitrary: true}
+	max := 2
+	assert.True(t, len([]rune(f.fillString(JSONSchema{MaxLength: &max}))) <= 2)
+}
+
+func TestFill_on_errors(t *testing.T) {
+	var obj TestFillStruct
+	assert.EqualError(t, Fill(obj, DefaultFillOptions), "Cannot use Fill on a non-struct pointer")
+	assert.EqualError(t, Fill(nil, DefaultFillOptions), "Cannot use Fill on a non-struct pointer")
+	assert.EqualError(t, Fill((*TestFillStruct)(nil), DefaultFillOptions), "Cannot use Fill on a non-struct pointer")
+	assert.EqualError(t, Fill(&obj, DefaultFillOptions), "Invalid fake generator: code in field Code")
+
+	cases := []struct {
+		obj      interface{}
+		expected string
+	}{
+		{&struct {
+			Value int `fake:"int:10-1"`
+		}{}, "Invalid fake range: 10-1 in field Value"},
+		{&struct {
+			Value int `fake:"int"`
+		}{}, "Invalid fake range:  in field Value"},
+		{&struct {
+			Value int `fake:"oneof:a,b"`
+		}{}, "Cannot fill Value: Invalid int value: a"},
+		{&struct {
+			Value time.Time `fake:"name"`
+		}{}, "Cannot fill Value: Provided value type (string) didn't match type (time.Time)"},
+		{&struct {
+			Values []bool `fake:"oneof:yes"`
+		}{}, "Cannot fill Values[0]: Invalid bool value: yes"},
+		{&struct {
+			Values map[string]uint `fake:"oneof:-1"`
+		}{}, "Cannot fill Values[nere]: Invalid uint value: -1"},
+		{&struct {
+			Value Optional[float64] `fake:"oneof:a"`
+		}{}, "Cannot fill Value: Invalid float64 value: a"},
+		{&struct {
+			Value int `validate:"max=a"`
+		}{}, "Invalid validate tag on field Value: strconv.ParseFloat: parsing \"a\": invalid syntax"},
+		{&struct {
+			Value int8 `validate:"gt=1,lt=2"`
+		}{}, "Cannot fill Value: no int8 value matches its constraints"},
+		{&struct {
+			Value uint `validate:"max=-1"`
+		}{}, "Cannot fill Value: no uint value matches its constraints"},
+		{&struct {
+			Value float32 `validate:"min=2,max=1"`
+		}{}, "Cannot fill Value: no float32 value matches its constraints"},
+		{&struct {
+			Value *int `fake:"oneof:a"`
+		}{}, "Cannot fill Value: Invalid int value: a"},
+	}
+	for _, c := range cases {
+		assert.EqualError(t, Fill(c.obj, DefaultFillOptions), c.expected)
+	}
+
+	err := Fill(&struct{ Values map[TestFillStatus]int }{}, FillOptions{
+		MinItems: 1,
+		Generators: map[reflect.Type]FakeGenerator{
+			reflect.TypeOf(TestFillStatus("")): func(r *rand.Rand) interface{} { return 1 },
+		},
+	})
+	assert.EqualError(t, err, "Cannot fill Values: Provided value type (int) didn't match type (reflectme.TestFillStatus)")
+}
@@ -0,0 +1,247 @@
+package reflectme
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"math"
+	"reflect"
+	"sort"
+)
+
+// hashCycle is hashed instead of a pointer, map or slice already hashed in
+// the current path
+const hashCycle = math.MaxUint64
+
+type (
+	// HashOptions are options for hash functions
+	HashOptions struct {
+		// ExcludePaths are not hashed, with the same rules as
+		// EqualOptions.IgnorePaths
+		ExcludePaths []string
+		// IncludeUnexported hashes the unexported struct fields too
+		IncludeUnexported bool
+	}
+
+	hasher struct {
+		options HashOptions
+		exclude []string
+		visited map[visitedValue]bool
+	}
+)
+
+// Hash returns a 64-bit FNV-1a hash of obj that is stable across process
+// runs. Struct fields are hashed by name, so their order doesn't matter,
+// map entries are sorted and fields tagged `hash:"-"` are skipped. Types
+// implementing encoding.BinaryMarshaler, like time.Time, are hashed by
+// their binary form. Pointers hash like the values they point to, nil
+// and empty slices and maps hash the same, and non-nil funcs, channels
+// and unsafe pointers can't be hashed. Cyclic pointers, maps and slices
+// are hashed once per path.
+func Hash(obj interface{}, options HashOptions) (uint64, error) {
+	h := fnv.New64a()
+	if err := newHasher(options).write(h, reflect.ValueOf(obj), ""); err != nil {
+		return 0, err
+	}
+
+	return h.Sum64(), nil
+}
+
+// Hash128 returns a 128-bit FNV-1a hash of obj, like Hash
+func Hash128(obj interface{}, options HashOptions) ([16]byte, error) {
+	var sum [16]byte
+	h := fnv.New128a()
+	if err := newHasher(options).write(h, reflect.ValueOf(obj), ""); err != nil {
+		return sum, err
+	}
+	copy(sum[:], h.Sum(nil))
+
+	return sum, nil
+}
+
+func newHasher(options HashOptions) *hasher {
+	h := &hasher{options: options, visited: make(map[visitedValue]bool)}
+	for _, path := range options.ExcludePaths {
+		h.exclude = append(h.exclude, pathIndexReplacer.Replace(path))
+	}
+
+	return h
+}
+
+func (h *hasher) excludes(path string) bool {
+	return len(h.exclude) > 0 && matchesAnyPath(h.exclude, pathIndexReplacer.Replace(path))
+}
+
+func (h *hasher) write(w io.Writer, v reflect.Value, path string) error {
+	kind := v.Kind()
+	if (kind == reflect.Ptr || kind == reflect.Map || kind == reflect.Slice) && !v.IsNil() {
+		visit := newVisitedValue(v)
+		if h.visited[visit] {
+			writeHashUint(w, hashCycle)
+			return nil
+		}
+		h.visited[visit] = true
+		defer delete(h.visited, visit)
+	}
+
+	// Pointers hash like their values and nil like an invalid value
+	if kind == reflect.Ptr && !v.IsNil() {
+		return h.write(w, v.Elem(), path)
+	}
+	if !v.IsValid() || v.Kind() == reflect.Ptr {
+		writeHashUint(w, uint64(reflect.Invalid))
+		return nil
+	}
+	writeHashUint(w, uint64(v.Kind()))
+
+	if v.Kind() != reflect.Interface && v.CanInterface() {
+		if o, ok := v.Interface().(optional); ok {
+			value, state := o.optionalValue()
+			writeHashUint(w, uint64(state))
+			return h.write(w, reflect.ValueOf(value), path)
+		}
+		if m, ok := v.Interface().(encoding.BinaryMarshaler); ok {
+			data, err := m.MarshalBinary()
+			if err != nil {
+				return fmt.Errorf("Cannot hash %s: %v", path, err)
+			}
+			writeHashBytes(w, data)
+			return nil
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			writeHashUint(w, 0)
+			return nil
+		}
+		writeHashUint(w, 1)
+		writeHashBytes(w, []byte(v.Elem().Type().String()))
+		return h.write(w, v.Elem(), path)
+	case reflect.Struct:
+		return h.writeStruct(w, v, path)
+	case reflect.Slice, reflect.Array:
+		writeHashUint(w, uint64(v.Len()))
+		for i := 0; i < v.Len(); i++ {
+			elemPath := fmt.Sprintf("%s[%d]", path, i)
+			if h.excludes(elemPath) {
+				continue
+			}
+			if err := h.write(w, v.Index(i), elemPath); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		return h.writeMap(w, v, path)
+	case reflect.Bool:
+		if v.Bool() {
+			writeHashUint(w, 1)
+		} else {
+			writeHashUint(w, 0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeHashUint(w, uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		writeHashUint(w, v.Uint())
+	case reflect.Float32, reflect.Float64:
+		writeHashFloat(w, v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		writeHashFloat(w, real(v.Complex()))
+		writeHashFloat(w, imag(v.Complex()))
+	case reflect.String:
+		writeHashBytes(w, []byte(v.String()))
+	default:
+		if !v.IsNil() {
+			return fmt.Errorf("Cannot hash %s: unsupported %v value", path, v.Type())
+		}
+		writeHashUint(w, 0)
+	}
+
+	return nil
+}
+
+func (h *hasher) writeStruct(w io.Writer, v reflect.Value, path string) error {
+	t := v.Type()
+	fields := make([]reflect.StructField, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if (isExportableField(field) || h.options.IncludeUnexported) && field.Tag.Get("hash") != "-" {
+			fields = append(fields, field)
+		}
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].Name < fields[j].Name
+	})
+
+	for _, field := range fields {
+		fieldPath := field.Name
+		if len(path) > 0 {
+			fieldPath = path + "." + field.Name
+		}
+		if h.excludes(fieldPath) {
+			continue
+		}
+		writeHashBytes(w, []byte(field.Name))
+		if err := h.write(w, v.FieldByIndex(field.Index), fieldPath); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeMap hashes the map entries sorted by their hashed bytes
+func (h *hasher) writeMap(w io.Writer, v reflect.Value, path string) error {
+	var entries [][]byte
+	iter := v.MapRange()
+	for iter.Next() {
+		entryPath := fmt.Sprintf("%s[%v]", path, iter.Key())
+		if h.excludes(entryPath) {
+			continue
+		}
+		var entry bytes.Buffer
+		if err := h.write(&entry, iter.Key(), path); err != nil {
+			return err
+		}
+		if err := h.write(&entry, iter.Value(), entryPath); err != nil {
+			return err
+		}
+		entries = append(entries, entry.Bytes())
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return bytes.Compare(entries[i], entries[j]) < 0
+	})
+
+	writeHashUint(w, uint64(len(entries)))
+	for _, entry := range entries {
+		writeHashBytes(w, entry)
+	}
+
+	return nil
+}
+
+func writeHashUint(w io.Writer, n uint64) {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], n)
+	w.Write(b[:])
+}
+
+func writeHashBytes(w io.Writer, b []byte) {
+	writeHashUint(w, uint64(len(b)))
+	w.Write(b)
+}
+
+// writeHashFloat hashes f with a single representation for zeros and NaNs
+func writeHashFloat(w io.Writer, f float64) {
+	switch {
+	case f == 0:
+		f = 0
+	case math.IsNaN(f):
+		f = math.NaN()
+	}
+	writeHashUint(w, math.Float64bits(f))
+}
@@ -0,0 +1,225 @@
+package reflectme
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TestHashStruct struct {
+	ID      int
+	Name    string
+	Version int `hash:"-"`
+	secret  string
+}
+
+type TestHashReordered struct {
+	Name string
+	ID   int
+}
+
+type TestHashMarshaler struct {
+	err error
+}
+
+func (m TestHashMarshaler) MarshalBinary() ([]byte, error) {
+	return nil, m.err
+}
+
+func hashOf(t *testing.T, obj interface{}, options HashOptions) uint64 {
+	hash, err := Hash(obj, options)
+	assert.NoError(t, err)
+
+	return hash
+}
+
+func TestHash_is_stable(t *testing.T) {
+	assert.Equal(t, uint64(0xb7850edb7b1fcab8), hashOf(t, TestHashStruct{ID: 1, Name: "name"}, HashOptions{}))
+}
+
+func TestHash_on_field_order(t *testing.T) {
+	assert.Equal(t,
+		hashOf(t, TestHashStruct{ID: 1, Name: "name"}, HashOptions{}),
+		hashOf(t, TestHashReordered{ID: 1, Name: "name"}, HashOptions{}))
+}
+
+func TestHash_on_different_values(t *testing.T) {
+	cases := []struct {
+		a, b interface{}
+	}{
+		{1, 2},
+		{"name", "other"},
+		{true, false},
+		{1.5, 1.25},
+		{uint8(2), uint8(3)},
+		{complex64(1 + 2i), complex64(1 + 3i)},
+		{1, int64(1)},
+		{nil, 0},
+		{TestHashStruct{ID: 1}, TestHashStruct{ID: 2}},
+	}
+	for _, c := range cases {
+		assert.NotEqual(t, hashOf(t, c.a, HashOptions{}), hashOf(t, c.b, HashOptions{}), "%v", c.a)
+	}
+}
+
+func TestHash_on_floats(t *testing.T) {
+	assert.Equal(t, hashOf(t, 0.0, HashOptions{}), hashOf(t, math.Copysign(0, -1), HashOptions{}))
+	assert.Equal(t,
+		hashOf(t, math.NaN(), HashOptions{}),
+		hashOf(t, math.Float64frombits(0x7ff8000000000002), HashOptions{}))
+}
+
+func TestHash_on_skipped_fields(t *testing.T) {
+	hash := hashOf(t, TestHashStruct{ID: 1}, HashOptions{})
+
+	assert.Equal(t, hash, hashOf(t, TestHashStruct{ID: 1, Version: 2, secret: "secret"}, HashOptions{}))
+}
+
+func TestHash_with_include_unexported(t *testing.T) {
+	options := HashOptions{IncludeUnexported: true}
+
+	assert.NotEqual(t,
+		hashOf(t, TestHashStruct{ID: 1}, options),
+		hashOf(t, TestHashStruct{ID: 1, secret: "secret"}, options))
+}
+
+func TestHash_on_maps(t *testing.T) {
+	hash := hashOf(t, map[string]int{"a": 1, "b": 2, "c": 3}, HashOptions{})
+
+	assert.Equal(t, hash, hashOf(t, map[string]int{"c": 3, "b": 2, "a": 1}, HashOptions{}))
+	assert.NotEqual(t, hash, hashOf(t, map[string]int{"a": 1, "b": 3, "c": 2}, HashOptions{}))
+}
+
+func TestHash_on_slices_and_arrays(t *testing.T) {
+	assert.NotEqual(t,
+		hashOf(t, []string{"a", "b"}, HashOptions{}),
+		hashOf(t, []string{"b", "a"}, HashOptions{}))
+	assert.Equal(t, hashOf(t, []int(nil), HashOptions{}), hashOf(t, []int{}, HashOptions{}))
+	assert.Equal(t, hashOf(t, map[string]int(nil), HashOptions{}), hashOf(t, map[string]int{}, HashOptions{}))
+	assert.NotEqual(t, hashOf(t, []int(nil), HashOptions{}), hashOf(t, [0]int{}, HashOptions{}))
+}
+
+func TestHash_on_pointers(t *testing.T) {
+	obj := TestHashStruct{ID: 1}
+
+	assert.Equal(t, hashOf(t, obj, HashOptions{}), hashOf(t, &obj, HashOptions{}))
+	assert.Equal(t, hashOf(t, nil, HashOptions{}), hashOf(t, (*TestHashStruct)(nil), HashOptions{}))
+}
+
+func TestHash_on_interfaces(t *testing.T) {
+	hash := hashOf(t, struct{ Any interface{} }{1}, HashOptions{})
+
+	assert.NotEqual(t, hash, hashOf(t, struct{ Any interface{} }{int64(1)}, HashOptions{}))
+	assert.NotEqual(t, hash, hashOf(t, struct{ Any interface{} }{}, HashOptions{}))
+}
+
+func TestHash_on_binary_marshalers(t *testing.T) {
+	date := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	hash := hashOf(t, date, HashOptions{})
+
+	assert.Equal(t, hash, hashOf(t, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), HashOptions{}))
+	assert.NotEqual(t, hash, hashOf(t, date.Add(time.Second), HashOptions{}))
+}
+
+func TestHash_on_optionals(t *testing.T) {
+	some := hashOf(t, Some(0), HashOptions{})
+	null := hashOf(t, Null[int](), HashOptions{})
+	absent := hashOf(t, Optional[int]{}, HashOptions{})
+
+	assert.NotEqual(t, some, null)
+	assert.NotEqual(t, some, absent)
+	assert.NotEqual(t, null, absent)
+}
+
+func TestHash_with_exclude_paths(t *testing.T) {
+	type excluded struct {
+		ID     int
+		Labels map[string]int
+		Nested *NestedStruct
+		Tags   []string
+	}
+	options := HashOptions{ExcludePaths: []string{"ID", "Labels[a]", "Nested.Dummy", "Tags[*]"}}
+	hash := hashOf(t, excluded{ID: 1, Labels: map[string]int{"a": 1}, Nested: &NestedStruct{}, Tags: []string{"b"}}, options)
+
+	obj := excluded{ID: 2, Labels: map[string]int{"a": 2}, Nested: &NestedStruct{Dummy: "dummy"}, Tags: []string{"a"}}
+	assert.Equal(t, hash, hashOf(t, obj, options))
+
+	obj.Nested.Yummy = 1
+	assert.NotEqual(t, hash, hashOf(t, obj, options))
+}
+
+func TestHash_on_pointer_cycles(t *testing.T) {
+	a := &TestRecursiveStruct{Dummy: "a"}
+	a.Next = a
+
+	assert.NotEqual(t,
+		hashOf(t, a, HashOptions{}),
+		hashOf(t, &TestRecursiveStruct{Dummy: "a", Next: &TestRecursiveStruct{Dummy: "a"}}, HashOptions{}))
+}
+
+func TestHash_on_map_cycles(t *testing.T) {
+	m := map[string]interface{}{"name": "name"}
+	m["self"] = m
+
+	assert.NotEqual(t,
+		hashOf(t, m, HashOptions{}),
+		hashOf(t, map[string]interface{}{"name": "name", "self": map[string]interface{}{"name": "name"}}, HashOptions{}))
+}
+
+func TestHash_on_slice_cycles(t *testing.T) {
+	s := []interface{}{1, nil}
+	s[1] = s
+
+	assert.NotEqual(t,
+		hashOf(t, s, HashOptions{}),
+		hashOf(t, []interface{}{1, []interface{}{1, nil}}, HashOptions{}))
+
+	// Slices sharing their first element aren't cycles
+	s = []interface{}{nil, "b"}
+	s[0] = s[:1]
+	assert.NotEqual(t, hashOf(t, s, HashOptions{}), hashOf(t, []interface{}{nil, "b"}, HashOptions{}))
+}
+
+func TestHash_on_errors(t *testing.T) {
+	_, err := Hash(struct{ Func func() }{func() {}}, HashOptions{})
+	assert.EqualError(t, err, "Cannot hash Func: unsupported func() value")
+
+	_, err = Hash(map[string]chan int{"a": make(chan int)}, HashOptions{})
+	assert.EqualError(t, err, "Cannot hash [a]: unsupported chan int value")
+
+	_, err = Hash([]interface{}{1, func() {}}, HashOptions{})
+	assert.EqualError(t, err, "Cannot hash [1]: unsupported func() value")
+
+	_, err = Hash(map[interface{}]int{make(chan int): 1}, HashOptions{})
+	assert.Error(t, err)
+
+	_, err = Hash(struct{ Marshaler TestHashMarshaler }{TestHashMarshaler{errors.New("marshal")}}, HashOptions{})
+	assert.EqualError(t, err, "Cannot hash Marshaler: marshal")
+}
+
+func TestHash_on_nil_funcs(t *testing.T) {
+	_, err := Hash(struct{ Func func() }{}, HashOptions{})
+	assert.NoError(t, err)
+}
+
+func TestHash128(t *testing.T) {
+	hash, err := Hash128(TestHashStruct{ID: 1}, HashOptions{})
+	assert.NoError(t, err)
+	assert.NotEqual(t, [16]byte{}, hash)
+
+	otherHash, err := Hash128(TestHashStruct{ID: 1, Version: 2}, HashOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, hash, otherHash)
+
+	otherHash, err = Hash128(TestHashStruct{ID: 2}, HashOptions{})
+	assert.NoError(t, err)
+	assert.NotEqual(t, hash, otherHash)
+}
+
+func TestHash128_on_errors(t *testing.T) {
+	_, err := Hash128(struct{ Func func() }{func() {}}, HashOptions{})
+	assert.EqualError(t, err, "Cannot hash Func: unsupported func() value")
+}
@@ -0,0 +1,341 @@
+package reflectme
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// JSONSchemaDraft is the JSON Schema dialect used by GenerateJSONSchema
+const JSONSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+type (
+	// JSONSchema is a JSON Schema document or subschema. It can be
+	// marshaled with encoding/json.
+	JSONSchema struct {
+		Schema               string                 `json:"$schema,omitempty"`
+		Ref                  string                 `json:"$ref,omitempty"`
+		Type                 interface{}            `json:"type,omitempty"`
+		Description          string                 `json:"description,omitempty"`
+		Format               string                 `json:"format,omitempty"`
+		ContentEncoding      string                 `json:"contentEncoding,omitempty"`
+		Enum                 []interface{}          `json:"enum,omitempty"`
+		Minimum              *float64               `json:"minimum,omitempty"`
+		Maximum              *float64               `json:"maximum,omitempty"`
+		ExclusiveMinimum     *float64               `json:"exclusiveMinimum,omitempty"`
+		ExclusiveMaximum     *float64               `json:"exclusiveMaximum,omitempty"`
+		MinLength            *int                   `json:"minLength,omitempty"`
+		MaxLength            *int                   `json:"maxLength,omitempty"`
+		MinItems             *int                   `json:"minItems,omitempty"`
+		MaxItems             *int                   `json:"maxItems,omitempty"`
+		Items                *JSONSchema            `json:"items,omitempty"`
+		Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+		Required             []string               `json:"required,omitempty"`
+		AdditionalProperties *JSONSchema            `json:"additionalProperties,omitempty"`
+		AnyOf                []*JSONSchema          `json:"anyOf,omitempty"`
+		Defs                 map[string]*JSONSchema `json:"$defs,omitempty"`
+	}
+
+	jsonSchemaGenerator struct {
+		root  reflect.Type
+		names map[reflect.Type]string
+		defs  map[string]*JSONSchema
+		err   error
+	}
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// GenerateJSONSchema generates a JSON Schema document for the obj type.
+// obj can whether be a structure or pointer to structure and may be a
+// nil pointer.
+//
+// Property names come from the json tag and fields are required unless
+// they are pointers or have the omitempty json option. Constraints are
+// read from the validate tag (required, min, max, len, gt, gte, lt, lte,
+// oneof and formats like email, url, uuid, ipv4, ipv6 and hostname) and
+// descriptions from the description tag. Named nested structs are put
+// in $defs and referenced with $ref, so recursive types are supported.
+func GenerateJSONSchema(obj interface{}) (*JSONSchema, error) {
+	if obj == nil {
+		return nil, errors.New("Cannot use GenerateJSONSchema on a nil interface")
+	}
+	t := structType(reflect.TypeOf(obj))
+	if t == nil {
+		return nil, errors.New("Cannot use GenerateJSONSchema on a non-struct interface")
+	}
+
+	g := &jsonSchemaGenerator{
+		root:  t,
+		names: make(map[reflect.Type]string),
+		defs:  make(map[string]*JSONSchema),
+	}
+	schema := g.structSchema(t)
+	if g.err != nil {
+		return nil, g.err
+	}
+	schema.Schema = JSONSchemaDraft
+	if len(g.defs) > 0 {
+		schema.Defs = g.defs
+	}
+
+	return schema, nil
+}
+
+func (g *jsonSchemaGenerator) typeSchema(t reflect.Type) *JSONSchema {
+	if t == timeType {
+		return &JSONSchema{Type: "string", Format: "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return g.typeSchema(t.Elem())
+	case reflect.Bool:
+		return &JSONSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &JSONSchema{Type: "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		minimum := 0.0
+		return &JSONSchema{Type: "integer", Minimum: &minimum}
+	case reflect.Float32, reflect.Float64:
+		return &JSONSchema{Type: "number"}
+	case reflect.String:
+		return &JSONSchema{Type: "string"}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &JSONSchema{Type: "string", ContentEncoding: "base64"}
+		}
+		return &JSONSchema{Type: "array", Items: g.typeSchema(t.Elem())}
+	case reflect.Array:
+		length := t.Len()
+		return &JSONSchema{Type: "array", Items: g.typeSchema(t.Elem()), MinItems: &length, MaxItems: &length}
+	case reflect.Map:
+		return &JSONSchema{Type: "object", AdditionalProperties: g.typeSchema(t.Elem())}
+	case reflect.Struct:
+		return g.structRef(t)
+	default:
+		// Interfaces accept any value
+		return &JSONSchema{}
+	}
+}
+
+// structRef returns a reference to the struct schema, adding it to
+// $defs the first time a named struct is found.
+func (g *jsonSchemaGenerator) structRef(t reflect.Type) *JSONSchema {
+	if t == g.root {
+		return &JSONSchema{Ref: "#"}
+	}
+	if t.Name() == "" {
+		return g.structSchema(t)
+	}
+	if name, ok := g.names[t]; ok {
+		return &JSONSchema{Ref: "#/$defs/" + name}
+	}
+
+	name := t.Name()
+	for i := 2; g.defs[name] != nil; i++ {
+		name = t.Name() + strconv.Itoa(i)
+	}
+	g.names[t] = name
+	// Reserve the name before generating so recursive fields find it
+	g.defs[name] = &JSONSchema{}
+	*g.defs[name] = *g.structSchema(t)
+
+	return &JSONSchema{Ref: "#/$defs/" + name}
+}
+
+func (g *jsonSchemaGenerator) structSchema(t reflect.Type) *JSONSchema {
+	schema := &JSONSchema{
+		Type:       "object",
+		Properties: make(map[string]*JSONSchema),
+	}
+	g.addProperties(schema, t, map[reflect.Type]bool{t: true})
+
+	return schema
+}
+
+// addProperties adds the t fields to schema. expanded are the struct
+// types whose fields were already added, so an embedded type repeated,
+// eg a struct embedding a pointer to itself, is only expanded once.
+func (g *jsonSchemaGenerator) addProperties(schema *JSONSchema, t reflect.Type, expanded map[reflect.Type]bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, options := parseTagValue(field.Tag.Get("json"))
+		if name == "-" && len(options) == 0 {
+			continue
+		}
+
+		// Embedded structs without a json name have their fields promoted
+		if embedded := structType(field.Type); field.Anonymous && name == "" && embedded != nil {
+			if !expanded[embedded] {
+				expanded[embedded] = true
+				g.addProperties(schema, embedded, expanded)
+			}
+			continue
+		}
+		if !isExportableField(field) {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property := g.typeSchema(field.Type)
+		required, err := applyValidateTag(property, field.Type, field.Tag.Get("validate"))
+		if err != nil && g.err == nil {
+			g.err = fmt.Errorf("Invalid validate tag on field %s: %v", field.Name, err)
+		}
+
+		omitEmpty := hasOption(options, "omitempty")
+		isPointer := field.Type.Kind() == reflect.Ptr
+		if isPointer && !omitEmpty {
+			property = nullableSchema(property)
+		}
+		if description := field.Tag.Get("description"); description != "" {
+			property.Description = description
+		}
+
+		schema.Properties[name] = property
+		if required || (!isPointer && !omitEmpty) {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
+
+// nullableSchema makes the schema also accept null, as encoding/json
+// writes nil pointers.
+func nullableSchema(schema *JSONSchema) *JSONSchema {
+	if typ, ok := schema.Type.(string); ok && schema.Ref == "" {
+		schema.Type = []string{typ, "null"}
+		return schema
+	}
+
+	return &JSONSchema{AnyOf: []*JSONSchema{schema, {Type: "null"}}}
+}
+
+// applyValidateTag adds the validate tag constraints to schema and
+// returns if the tag marks the field as required.
+func applyValidateTag(schema *JSONSchema, t reflect.Type, tag string) (bool, error) {
+	if tag == "" {
+		return false, nil
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	required := false
+	for _, rule := range strings.Split(tag, ",") {
+		key, value := rule, ""
+		if i := strings.Index(rule, "="); i > -1 {
+			key, value = rule[0:i], rule[i+1:]
+		}
+
+		var err error
+		switch key {
+		case "dive":
+			// Following rules apply to the collection elements
+			return required, nil
+		case "required":
+			required = true
+		case "min", "gte":
+			err = setLowerBound(schema, t, value, false)
+		case "max", "lte":
+			err = setUpperBound(schema, t, value, false)
+		case "gt":
+			err = setLowerBound(schema, t, value, true)
+		case "lt":
+			err = setUpperBound(schema, t, value, true)
+		case "len":
+			if err = setLowerBound(schema, t, value, false); err == nil {
+				err = setUpperBound(schema, t, value, false)
+			}
+		case "oneof":
+			err = setEnum(schema, t, value)
+		case "email", "uuid", "ipv4", "ipv6", "hostname":
+			schema.Format = key
+		case "url", "uri":
+			schema.Format = "uri"
+		}
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return required, nil
+}
+
+func setLowerBound(schema *JSONSchema, t reflect.Type, value string, exclusive bool) error {
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return err
+	}
+
+	length := int(n)
+	if exclusive {
+		length++
+	}
+	switch {
+	case isNumberKind(t.Kind()) && exclusive:
+		schema.ExclusiveMinimum = &n
+	case isNumberKind(t.Kind()):
+		schema.Minimum = &n
+	case t.Kind() == reflect.String:
+		schema.MinLength = &length
+	case isCollectionKind(t.Kind()):
+		schema.MinItems = &length
+	}
+
+	return nil
+}
+
+func setUpperBound(schema *JSONSchema, t reflect.Type, value string, exclusive bool) error {
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return err
+	}
+
+	length := int(n)
+	if exclusive {
+		length--
+	}
+	switch {
+	case isNumberKind(t.Kind()) && exclusive:
+		schema.ExclusiveMaximum = &n
+	case isNumberKind(t.Kind()):
+		schema.Maximum = &n
+	case t.Kind() == reflect.String:
+		schema.MaxLength = &length
+	case isCollectionKind(t.Kind()):
+		schema.MaxItems = &length
+	}
+
+	return nil
+}
+
+func setEnum(schema *JSONSchema, t reflect.Type, value string) error {
+	for _, option := range strings.Fields(value) {
+		if !isNumberKind(t.Kind()) {
+			schema.Enum = append(schema.Enum, option)
+			continue
+		}
+
+		n, err := strconv.ParseFloat(option, 64)
+		if err != nil {
+			return err
+		}
+		schema.Enum = append(schema.Enum, n)
+	}
+
+	return nil
+}
+
+func isNumberKind(k reflect.Kind) bool {
+	return (k >= reflect.Int && k <= reflect.Uintptr) || k == reflect.Float32 || k == reflect.Float64
+}
+
+func isCollectionKind(k reflect.Kind) bool {
+	return k == reflect.Slice || k == reflect.Array || k == reflect.Map
+}
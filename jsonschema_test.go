@@ -0,0 +1,175 @@
+package reflectme
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TestJSONSchemaAddress struct {
+	Street string `json:"street" validate:"required,min=3,max=100" description:"Street name"`
+	Zip    string `json:"zip,omitempty" validate:"len=5"`
+}
+
+type TestJSONSchemaStruct struct {
+	NestedStruct
+	ID        string                  `json:"id" validate:"uuid"`
+	Email     string                  `json:"email" validate:"email"`
+	Age       uint8                   `json:"age" validate:"gt=17,lte=130"`
+	Score     float64                 `json:"score,omitempty" validate:"gte=0,lt=10"`
+	Status    string                  `json:"status" validate:"oneof=active inactive"`
+	Level     int                     `json:"level" validate:"oneof=1 2 3"`
+	Tags      []string                `json:"tags" validate:"min=1,dive,min=2"`
+	Raw       []byte                  `json:"raw,omitempty"`
+	Pair      [2]bool                 `json:"pair"`
+	Labels    map[string]string       `json:"labels,omitempty"`
+	Any       interface{}             `json:"any,omitempty"`
+	CreatedAt time.Time               `json:"created_at"`
+	Home      TestJSONSchemaAddress   `json:"home"`
+	Work      *TestJSONSchemaAddress  `json:"work"`
+	Others    []TestJSONSchemaAddress `json:"others,omitempty" validate:"max=5"`
+	Site      string                  `json:"site" validate:"url"`
+	Parent    *TestJSONSchemaStruct   `json:"parent,omitempty"`
+	Inline    struct {
+		Value int `json:"value"`
+	} `json:"inline"`
+	Ignored    string `json:"-"`
+	unexported string
+}
+
+func TestGenerateJSONSchema_on_struct(t *testing.T) {
+	schema, err := GenerateJSONSchema(TestJSONSchemaStruct{})
+	assert.NoError(t, err)
+	assert.Equal(t, JSONSchemaDraft, schema.Schema)
+	assert.Equal(t, "object", schema.Type)
+	assert.Equal(t, []string{
+		"Dummy", "Yummy", "id", "email", "age", "status", "level", "tags", "pair", "created_at", "home", "site", "inline",
+	}, schema.Required)
+
+	props := schema.Properties
+	assert.Equal(t, "integer", props["Yummy"].Type)
+	assert.Equal(t, "uuid", props["id"].Format)
+	assert.Equal(t, "email", props["email"].Format)
+	assert.Equal(t, 17.0, *props["age"].ExclusiveMinimum)
+	assert.Equal(t, 130.0, *props["age"].Maximum)
+	assert.Equal(t, 0.0, *props["age"].Minimum)
+	assert.Equal(t, 0.0, *props["score"].Minimum)
+	assert.Equal(t, 10.0, *props["score"].ExclusiveMaximum)
+	assert.Equal(t, []interface{}{"active", "inactive"}, props["status"].Enum)
+	assert.Equal(t, []interface{}{1.0, 2.0, 3.0}, props["level"].Enum)
+	assert.Equal(t, 1, *props["tags"].MinItems)
+	assert.Nil(t, props["tags"].Items.MinLength)
+	assert.Equal(t, "base64", props["raw"].ContentEncoding)
+	assert.Equal(t, 2, *props["pair"].MaxItems)
+	assert.Equal(t, "string", props["labels"].AdditionalProperties.Type)
+	assert.Equal(t, &JSONSchema{}, props["any"])
+	assert.Equal(t, "date-time", props["created_at"].Format)
+	assert.Equal(t, "#/$defs/TestJSONSchemaAddress", props["home"].Ref)
+	assert.Equal(t, "#/$defs/TestJSONSchemaAddress", props["work"].AnyOf[0].Ref)
+	assert.Equal(t, "null", props["work"].AnyOf[1].Type)
+	assert.Equal(t, "#/$defs/TestJSONSchemaAddress", props["others"].Items.Ref)
+	assert.Equal(t, 5, *props["others"].MaxItems)
+	assert.Equal(t, "uri", props["site"].Format)
+	assert.Equal(t, "#", props["parent"].Ref)
+	assert.Equal(t, "integer", props["inline"].Properties["value"].Type)
+	assert.NotContains(t, props, "Ignored")
+	assert.NotContains(t, props, "unexported")
+
+	address := schema.Defs["TestJSONSchemaAddress"]
+	assert.Equal(t, []string{"street"}, address.Required)
+	assert.Equal(t, "Street name", address.Properties["street"].Description)
+	assert.Equal(t, 3, *address.Properties["street"].MinLength)
+	assert.Equal(t, 100, *address.Properties["street"].MaxLength)
+	assert.Equal(t, 5, *address.Properties["zip"].MinLength)
+	assert.Equal(t, 5, *address.Properties["zip"].MaxLength)
+}
+
+func TestGenerateJSONSchema_on_nil_struct_pointer(t *testing.T) {
+	schema, err := GenerateJSONSchema((*NestedStruct)(nil))
+	assert.NoError(t, err)
+
+	b, err := json.Marshal(schema)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"properties": {
+			"Dummy": {"type": "string"},
+			"Yummy": {"type": "integer"}
+		},
+		"required": ["Dummy", "Yummy"]
+	}`, string(b))
+}
+
+func TestGenerateJSONSchema_on_nullable_pointer(t *testing.T) {
+	dummyStruct := struct {
+		Name *string `json:"name" validate:"required,lt=10"`
+	}{}
+
+	schema, err := GenerateJSONSchema(dummyStruct)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"string", "null"}, schema.Properties["name"].Type)
+	assert.Equal(t, 9, *schema.Properties["name"].MaxLength)
+	assert.Equal(t, []string{"name"}, schema.Required)
+}
+
+type TestJSONSchemaRecursiveEmbedded struct {
+	*TestJSONSchemaRecursiveEmbedded
+	NestedStruct
+	Other *NestedStruct
+	Value int `json:"value"`
+}
+
+func TestGenerateJSONSchema_on_recursive_embedded_struct(t *testing.T) {
+	schema, err := GenerateJSONSchema(TestJSONSchemaRecursiveEmbedded{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Dummy", "Yummy", "value"}, schema.Required)
+	assert.Len(t, schema.Properties, 4)
+	assert.Equal(t, "integer", schema.Properties["value"].Type)
+}
+
+func TestGenerateJSONSchema_with_def_name_collision(t *testing.T) {
+	type NestedStruct struct {
+		Other int
+	}
+	dummyStruct := struct {
+		First  NestedStruct
+		Second TestNestedStruct
+	}{}
+
+	schema, err := GenerateJSONSchema(dummyStruct)
+	assert.NoError(t, err)
+	assert.Equal(t, "#/$defs/NestedStruct", schema.Properties["First"].Ref)
+	assert.Equal(t, "#/$defs/NestedStruct2", schema.Defs["TestNestedStruct"].Properties["Nested"].Ref)
+}
+
+func TestGenerateJSONSchema_with_invalid_validate_tag(t *testing.T) {
+	invalid := []interface{}{
+		struct {
+			Value int `validate:"min=a"`
+		}{},
+		struct {
+			Value int `validate:"max=a"`
+		}{},
+		struct {
+			Value int `validate:"len=a"`
+		}{},
+		struct {
+			Value int `validate:"oneof=1 a"`
+		}{},
+	}
+	for _, obj := range invalid {
+		_, err := GenerateJSONSchema(obj)
+		assert.Error(t, err)
+	}
+}
+
+func TestGenerateJSONSchema_on_non_struct(t *testing.T) {
+	_, err := GenerateJSONSchema("abc 123")
+	assert.Error(t, err)
+
+	_, err = GenerateJSONSchema(nil)
+	assert.Error(t, err)
+}
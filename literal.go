@@ -0,0 +1,362 @@
+package reflectme
+
+import (
+	"fmt"
+	"go/format"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// literalContext tells how much of a literal type its context already
+// gives
+type literalContext int
+
+const (
+	// literalUntyped needs the type of every literal, like an interface
+	literalUntyped literalContext = iota
+	// literalTyped gives the type of constants, like a struct field
+	literalTyped
+	// literalElided lets composite literals omit their type, like a
+	// slice element
+	literalElided
+)
+
+const goLiteralPrefix = "package p\n\nvar v = "
+
+var (
+	// typeArgPackagePath matches the package path of the type arguments
+	// in generic type names, eg "net/" in "Optional[net/url.URL]"
+	typeArgPackagePath = regexp.MustCompile(`([\w.-]+/)+`)
+
+	literalDefaultTypes = map[reflect.Kind]reflect.Type{
+		reflect.Bool:       reflect.TypeOf(false),
+		reflect.Int:        reflect.TypeOf(0),
+		reflect.Float64:    reflect.TypeOf(0.0),
+		reflect.Complex128: reflect.TypeOf(0i),
+		reflect.String:     reflect.TypeOf(""),
+	}
+)
+
+type goLiteral struct {
+	visited map[visitedValue]bool
+}
+
+// GoLiteral returns obj as gofmt formatted Go source that compiles to an
+// equal value, eg to paste production values into test fixtures:
+//   - structs, slices, arrays and maps are composite literals with
+//     package qualified type names, omitting the zero struct fields
+//   - pointers to composite values are &T{...} and the other ones are
+//     built by a func literal, eg func(v int) *int { return &v }(1)
+//   - time.Time is a time.Date call and Optional a Some or Null call
+//   - NaN and infinite floats use the math package
+//
+// Unexported fields are skipped. Pointer, map and slice cycles, non-nil
+// funcs, channels and unsafe pointers can't be rendered.
+func GoLiteral(obj interface{}) (string, error) {
+	var b strings.Builder
+	l := &goLiteral{visited: make(map[visitedValue]bool)}
+	if err := l.write(&b, reflect.ValueOf(obj), literalUntyped, ""); err != nil {
+		return "", err
+	}
+
+	src := []byte(goLiteralPrefix + b.String())
+	if formatted, err := format.Source(src); err == nil {
+		src = formatted
+	}
+
+	return strings.TrimSuffix(strings.TrimPrefix(string(src), goLiteralPrefix), "\n"), nil
+}
+
+func (l *goLiteral) write(b *strings.Builder, v reflect.Value, ctx literalContext, path string) error {
+	if !v.IsValid() {
+		b.WriteString("nil")
+		return nil
+	}
+
+	t := v.Type()
+	if t == timeType {
+		writeTimeLiteral(b, v.Interface().(time.Time))
+		return nil
+	}
+	if isOptionalType(t) {
+		return l.writeOptional(b, v, ctx, path)
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		writeBasicLiteral(b, t, strconv.FormatBool(v.Bool()), ctx)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeBasicLiteral(b, t, strconv.FormatInt(v.Int(), 10), ctx)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		writeBasicLiteral(b, t, strconv.FormatUint(v.Uint(), 10), ctx)
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			// math funcs return float64 values instead of constants
+			if t != literalDefaultTypes[reflect.Float64] {
+				ctx = literalUntyped
+			}
+		}
+		writeBasicLiteral(b, t, floatLiteral(f, t.Bits()), ctx)
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		bits := t.Bits() / 2
+		lit := fmt.Sprintf("complex(%s, %s)", floatLiteral(real(c), bits), floatLiteral(imag(c), bits))
+		writeBasicLiteral(b, t, lit, ctx)
+	case reflect.String:
+		writeBasicLiteral(b, t, strconv.Quote(v.String()), ctx)
+	case reflect.Interface:
+		return l.write(b, v.Elem(), literalUntyped, path)
+	case reflect.Ptr:
+		return l.writePointer(b, v, ctx, path)
+	case reflect.Struct:
+		return l.writeStruct(b, v, ctx, path)
+	case reflect.Slice, reflect.Array, reflect.Map:
+		if v.Kind() != reflect.Array && v.IsNil() {
+			writeNilLiteral(b, t, ctx)
+			return nil
+		}
+		return l.writeCollection(b, v, ctx, path)
+	default:
+		if !v.IsNil() {
+			return fmt.Errorf("Cannot render %s: unsupported %v value", literalPathName(path), t)
+		}
+		writeNilLiteral(b, t, ctx)
+	}
+
+	return nil
+}
+
+func (l *goLiteral) writePointer(b *strings.Builder, v reflect.Value, ctx literalContext, path string) error {
+	if v.IsNil() {
+		writeNilLiteral(b, v.Type(), ctx)
+		return nil
+	}
+
+	key := newVisitedValue(v)
+	if l.visited[key] {
+		return fmt.Errorf("Cannot render %s: pointer cycle", literalPathName(path))
+	}
+	l.visited[key] = true
+	defer delete(l.visited, key)
+
+	elem := v.Elem()
+	if isCompositeLiteralType(elem.Type()) && (elem.Kind() == reflect.Struct || elem.Kind() == reflect.Array || !elem.IsNil()) {
+		// &T{} elements of composite literals can omit &T like T{} can
+		if ctx != literalElided {
+			b.WriteString("&")
+		}
+		return l.write(b, elem, ctx, path)
+	}
+
+	elemType := literalTypeName(elem.Type())
+	fmt.Fprintf(b, "func(v %s) *%s { return &v }(", elemType, elemType)
+	if err := l.write(b, elem, literalTyped, path); err != nil {
+		return err
+	}
+	b.WriteString(")")
+
+	return nil
+}
+
+func (l *goLiteral) writeStruct(b *strings.Builder, v reflect.Value, ctx literalContext, path string) error {
+	var fields []string
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if !isExportableField(field) || v.Field(i).IsZero() {
+			continue
+		}
+
+		fieldPath := field.Name
+		if len(path) > 0 {
+			fieldPath = path + "." + field.Name
+		}
+		var fb strings.Builder
+		if err := l.write(&fb, v.Field(i), literalTyped, fieldPath); err != nil {
+			return err
+		}
+		fields = append(fields, field.Name+": "+fb.String())
+	}
+
+	writeCompositeLiteral(b, v.Type(), ctx, fields)
+	return nil
+}
+
+func (l *goLiteral) writeCollection(b *strings.Builder, v reflect.Value, ctx literalContext, path string) error {
+	if v.Kind() != reflect.Array {
+		key := newVisitedValue(v)
+		if l.visited[key] {
+			return fmt.Errorf("Cannot render %s: %v cycle", literalPathName(path), v.Kind())
+		}
+		l.visited[key] = true
+		defer delete(l.visited, key)
+	}
+
+	var elems []string
+	if v.Kind() == reflect.Map {
+		iter := v.MapRange()
+		for iter.Next() {
+			var kb, vb strings.Builder
+			if err := l.write(&kb, iter.Key(), literalElided, path); err != nil {
+				return err
+			}
+			if err := l.write(&vb, iter.Value(), literalElided, fmt.Sprintf("%s[%v]", path, iter.Key())); err != nil {
+				return err
+			}
+			elems = append(elems, kb.String()+": "+vb.String())
+		}
+		sort.Strings(elems)
+	} else {
+		// Zero array elements at the end can be omitted
+		n := v.Len()
+		for v.Kind() == reflect.Array && n > 0 && v.Index(n-1).IsZero() {
+			n--
+		}
+		for i := 0; i < n; i++ {
+			var eb strings.Builder
+			if err := l.write(&eb, v.Index(i), literalElided, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+			elems = append(elems, eb.String())
+		}
+	}
+
+	writeCompositeLiteral(b, v.Type(), ctx, elems)
+	return nil
+}
+
+func (l *goLiteral) writeOptional(b *strings.Builder, v reflect.Value, ctx literalContext, path string) error {
+	o := v.Interface().(optional)
+	value, state := o.optionalValue()
+	elemType := literalTypeName(o.optionalElem())
+	switch state {
+	case optionalAbsent:
+		writeCompositeLiteral(b, v.Type(), ctx, nil)
+		return nil
+	case optionalNull:
+		fmt.Fprintf(b, "reflectme.Null[%s]()", elemType)
+		return nil
+	}
+
+	fmt.Fprintf(b, "reflectme.Some[%s](", elemType)
+	elemCtx := literalTyped
+	if o.optionalElem().Kind() == reflect.Interface {
+		elemCtx = literalUntyped
+	}
+	if err := l.write(b, reflect.ValueOf(value), elemCtx, path); err != nil {
+		return err
+	}
+	b.WriteString(")")
+
+	return nil
+}
+
+// writeCompositeLiteral writes a composite literal of t with its
+// elements, in a single line when they are short
+func writeCompositeLiteral(b *strings.Builder, t reflect.Type, ctx literalContext, elems []string) {
+	if ctx != literalElided {
+		b.WriteString(literalTypeName(t))
+	}
+
+	joined := strings.Join(elems, ", ")
+	if len(joined) <= 60 && !strings.Contains(joined, "\n") {
+		b.WriteString("{" + joined + "}")
+		return
+	}
+
+	b.WriteString("{\n")
+	for _, elem := range elems {
+		b.WriteString(elem + ",\n")
+	}
+	b.WriteString("}")
+}
+
+// writeBasicLiteral writes lit, converted to t when the context doesn't
+// give its type
+func writeBasicLiteral(b *strings.Builder, t reflect.Type, lit string, ctx literalContext) {
+	if ctx != literalUntyped || t == literalDefaultTypes[t.Kind()] {
+		b.WriteString(lit)
+		return
+	}
+
+	fmt.Fprintf(b, "%s(%s)", literalTypeName(t), lit)
+}
+
+func writeNilLiteral(b *strings.Builder, t reflect.Type, ctx literalContext) {
+	if ctx != literalUntyped {
+		b.WriteString("nil")
+		return
+	}
+
+	fmt.Fprintf(b, "(%s)(nil)", literalTypeName(t))
+}
+
+func writeTimeLiteral(b *strings.Builder, t time.Time) {
+	loc := "time.UTC"
+	switch name, offset := t.Zone(); {
+	case t.Location() == time.Local:
+		loc = "time.Local"
+	case t.Location() != time.UTC:
+		loc = fmt.Sprintf("time.FixedZone(%q, %d)", name, offset)
+	}
+
+	fmt.Fprintf(b, "time.Date(%d, time.%s, %d, %d, %d, %d, %d, %s)",
+		t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+}
+
+// floatLiteral returns f as a float constant, or a math call for NaN and
+// infinite floats
+func floatLiteral(f float64, bits int) string {
+	switch {
+	case math.IsNaN(f):
+		return "math.NaN()"
+	case math.IsInf(f, 1):
+		return "math.Inf(1)"
+	case math.IsInf(f, -1):
+		return "math.Inf(-1)"
+	}
+
+	lit := strconv.FormatFloat(f, 'g', -1, bits)
+	if !strings.ContainsAny(lit, ".e") {
+		lit += ".0"
+	}
+
+	return lit
+}
+
+// literalTypeName returns the t name as Go source, with package names
+// instead of package paths in generic type arguments
+func literalTypeName(t reflect.Type) string {
+	name := t.String()
+	if strings.Contains(name, "[") {
+		name = typeArgPackagePath.ReplaceAllString(name, "")
+	}
+
+	return name
+}
+
+// isCompositeLiteralType indicates if t values are written as composite
+// literals
+func isCompositeLiteralType(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Struct:
+		return t != timeType && !isOptionalType(t)
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return true
+	}
+
+	return false
+}
+
+func literalPathName(path string) string {
+	if len(path) == 0 {
+		return "obj"
+	}
+
+	return path
+}
@@ -0,0 +1,241 @@
+package reflectme
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TestLiteralStatus string
+
+type TestLiteralItem struct {
+	ID   int
+	Name string
+}
+
+type TestLiteralStruct struct {
+	Name   string
+	Zero   string
+	secret string
+}
+
+func goLiteralString(t *testing.T, obj interface{}) string {
+	src, err := GoLiteral(obj)
+	assert.NoError(t, err)
+
+	return src
+}
+
+func TestGoLiteral_on_struct(t *testing.T) {
+	obj := TestLiteralStruct{Name: "name", secret: "secret"}
+
+	assert.Equal(t, `reflectme.TestLiteralStruct{Name: "name"}`, goLiteralString(t, obj))
+}
+
+func TestGoLiteral_on_struct_fields(t *testing.T) {
+	obj := struct {
+		Count   int64
+		Ratio   float32
+		Score   float64
+		Complex complex64
+		Active  bool
+		Status  TestLiteralStatus
+	}{Count: 2, Ratio: 0.1, Score: 1, Complex: 1 + 2i, Active: true, Status: "active"}
+
+	assert.Equal(t, `struct {
+	Count   int64
+	Ratio   float32
+	Score   float64
+	Complex complex64
+	Active  bool
+	Status  reflectme.TestLiteralStatus
+}{
+	Count:   2,
+	Ratio:   0.1,
+	Score:   1.0,
+	Complex: complex(1.0, 2.0),
+	Active:  true,
+	Status:  "active",
+}`, goLiteralString(t, obj))
+}
+
+func TestGoLiteral_on_basic_values(t *testing.T) {
+	cases := map[string]interface{}{
+		"nil":                              nil,
+		"1":                                1,
+		"int32(1)":                         int32(1),
+		"1.5":                              1.5,
+		`"s"`:                              "s",
+		"true":                             true,
+		"complex(0.0, 1.0)":                1i,
+		"uint8(1)":                         uint8(1),
+		`reflectme.TestLiteralStatus("s")`: TestLiteralStatus("s"),
+	}
+	for expected, obj := range cases {
+		assert.Equal(t, expected, goLiteralString(t, obj))
+	}
+}
+
+func TestGoLiteral_on_special_floats(t *testing.T) {
+	cases := map[string]interface{}{
+		"math.NaN()":           math.NaN(),
+		"math.Inf(-1)":         math.Inf(-1),
+		"float32(math.Inf(1))": float32(math.Inf(1)),
+	}
+	for expected, obj := range cases {
+		assert.Equal(t, expected, goLiteralString(t, obj))
+	}
+	assert.Equal(t, "struct{ Ratio float32 }{Ratio: float32(math.NaN())}", goLiteralString(t, struct{ Ratio float32 }{float32(math.NaN())}))
+}
+
+func TestGoLiteral_on_pointers(t *testing.T) {
+	count := 3
+	values := []int{1}
+	cases := map[string]interface{}{
+		"&reflectme.NestedStruct{Dummy: \"dummy\"}": &NestedStruct{Dummy: "dummy"},
+		"func(v int) *int { return &v }(3)":         &count,
+		"&[]int{1}":                                 &values,
+		"&[2]int{1}":                                &[2]int{1, 0},
+		"func(v []int) *[]int { return &v }(nil)":   new([]int),
+		"(*int)(nil)":                               (*int)(nil),
+	}
+	for expected, obj := range cases {
+		assert.Equal(t, expected, goLiteralString(t, obj))
+	}
+}
+
+func TestGoLiteral_on_interfaces(t *testing.T) {
+	obj := struct {
+		Any    interface{}
+		Values []interface{}
+	}{Any: int64(1), Values: []interface{}{1, nil, []int(nil), TestLiteralItem{ID: 1}}}
+
+	assert.Equal(t, `struct {
+	Any    interface{}
+	Values []interface{}
+}{
+	Any:    int64(1),
+	Values: []interface{}{1, nil, ([]int)(nil), reflectme.TestLiteralItem{ID: 1}},
+}`, goLiteralString(t, obj))
+}
+
+func TestGoLiteral_on_slices(t *testing.T) {
+	cases := map[string]interface{}{
+		"[]int{}":            []int{},
+		"([]int)(nil)":       []int(nil),
+		`[]string{"a", "b"}`: []string{"a", "b"},
+		"[]*reflectme.TestLiteralItem{{ID: 1}, nil}": []*TestLiteralItem{{ID: 1}, nil},
+	}
+	for expected, obj := range cases {
+		assert.Equal(t, expected, goLiteralString(t, obj))
+	}
+}
+
+func TestGoLiteral_on_long_slices(t *testing.T) {
+	items := []TestLiteralItem{{ID: 1, Name: "first item with a long name"}, {ID: 2, Name: "second item"}}
+
+	assert.Equal(t, `[]reflectme.TestLiteralItem{
+	{ID: 1, Name: "first item with a long name"},
+	{ID: 2, Name: "second item"},
+}`, goLiteralString(t, items))
+}
+
+func TestGoLiteral_on_arrays(t *testing.T) {
+	assert.Equal(t, "[2]int{1, 2}", goLiteralString(t, [2]int{1, 2}))
+	assert.Equal(t, "[3]int{0, 1}", goLiteralString(t, [3]int{0, 1, 0}))
+}
+
+func TestGoLiteral_on_maps(t *testing.T) {
+	count := 3
+	cases := map[string]interface{}{
+		`map[string]int{"a": 1, "b": 2}`: map[string]int{"b": 2, "a": 1},
+		"(map[string]int)(nil)":          map[string]int(nil),
+		"map[reflectme.TestLiteralItem]*int{{ID: 1}: func(v int) *int { return &v }(3)}": map[TestLiteralItem]*int{{ID: 1}: &count},
+	}
+	for expected, obj := range cases {
+		assert.Equal(t, expected, goLiteralString(t, obj))
+	}
+}
+
+func TestGoLiteral_on_times(t *testing.T) {
+	cases := map[string]interface{}{
+		"time.Date(2020, time.January, 2, 3, 4, 5, 6, time.UTC)":                        time.Date(2020, 1, 2, 3, 4, 5, 6, time.UTC),
+		"time.Date(2020, time.January, 1, 0, 0, 0, 0, time.FixedZone(\"UTC+1\", 3600))": time.Date(2020, 1, 1, 0, 0, 0, 0, time.FixedZone("UTC+1", 3600)),
+		"time.Date(2020, time.January, 1, 0, 0, 0, 0, time.Local)":                      time.Date(2020, 1, 1, 0, 0, 0, 0, time.Local),
+	}
+	for expected, obj := range cases {
+		assert.Equal(t, expected, goLiteralString(t, obj))
+	}
+}
+
+func TestGoLiteral_on_optionals(t *testing.T) {
+	cases := map[string]interface{}{
+		`reflectme.Some[string]("note")`:       Some("note"),
+		"reflectme.Null[int]()":                Null[int](),
+		"reflectme.Optional[int]{}":            Optional[int]{},
+		"reflectme.Some[interface{}](int8(1))": Some[interface{}](int8(1)),
+		"reflectme.Some[reflectme.NestedStruct](reflectme.NestedStruct{Yummy: 1})":                                                    Some(NestedStruct{Yummy: 1}),
+		"[]reflectme.Optional[int]{{}, reflectme.Some[int](1)}":                                                                       []Optional[int]{{}, Some(1)},
+		"reflectme.Some[*time.Time](func(v time.Time) *time.Time { return &v }(time.Date(1, time.January, 1, 0, 0, 0, 0, time.UTC)))": Some(&time.Time{}),
+	}
+	for expected, obj := range cases {
+		assert.Equal(t, expected, goLiteralString(t, obj))
+	}
+}
+
+func TestGoLiteral_on_nil_funcs(t *testing.T) {
+	assert.Equal(t, "(func())(nil)", goLiteralString(t, (func())(nil)))
+}
+
+func TestGoLiteral_on_pointer_cycles(t *testing.T) {
+	recursive := &TestRecursiveStruct{Dummy: "a"}
+	recursive.Next = recursive
+
+	_, err := GoLiteral(recursive)
+	assert.EqualError(t, err, "Cannot render Next: pointer cycle")
+}
+
+func TestGoLiteral_on_map_cycles(t *testing.T) {
+	m := map[string]interface{}{"name": "name"}
+	m["self"] = m
+
+	_, err := GoLiteral(m)
+	assert.EqualError(t, err, "Cannot render [self]: map cycle")
+}
+
+func TestGoLiteral_on_slice_cycles(t *testing.T) {
+	s := []interface{}{1, nil}
+	s[1] = s
+
+	_, err := GoLiteral(s)
+	assert.EqualError(t, err, "Cannot render [1]: slice cycle")
+
+	// Slices sharing their first element aren't cycles
+	s = []interface{}{nil, "b"}
+	s[1] = s[:1]
+	assert.Equal(t, `[]interface{}{nil, []interface{}{nil}}`, goLiteralString(t, s))
+}
+
+func TestGoLiteral_on_errors(t *testing.T) {
+	f := func() {}
+	ch := make(chan int)
+	cases := []struct {
+		obj      interface{}
+		expected string
+	}{
+		{f, "Cannot render obj: unsupported func() value"},
+		{&ch, "Cannot render obj: unsupported chan int value"},
+		{[]interface{}{f}, "Cannot render [0]: unsupported func() value"},
+		{map[string]interface{}{"a": f}, "Cannot render [a]: unsupported func() value"},
+		{map[chan int]int{ch: 1}, "Cannot render obj: unsupported chan int value"},
+		{struct{ Func func() }{f}, "Cannot render Func: unsupported func() value"},
+		{&struct{ Func func() }{f}, "Cannot render Func: unsupported func() value"},
+		{Some(f), "Cannot render obj: unsupported func() value"},
+	}
+	for _, c := range cases {
+		_, err := GoLiteral(c.obj)
+		assert.EqualError(t, err, c.expected)
+	}
+}
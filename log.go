@@ -0,0 +1,196 @@
+package reflectme
+
+import (
+	"context"
+	"encoding"
+	"fmt"
+	"log/slog"
+	"reflect"
+)
+
+type (
+	// LogOptions are options for log functions
+	LogOptions struct {
+		// MaxDepth is the maximum struct nesting logged, 0 means no limit.
+		// Deeper structs are omitted.
+		MaxDepth int
+		// OmitZero skips the zero value fields, like the omitempty option
+		// of the log tag
+		OmitZero bool
+	}
+
+	logger struct {
+		options LogOptions
+		visited map[visitedPointer]bool
+	}
+
+	logHandler struct {
+		slog.Handler
+		options LogOptions
+	}
+)
+
+var (
+	// DefaultLogOptions are the default options for log functions
+	DefaultLogOptions = LogOptions{}
+
+	logValuerType     = reflect.TypeOf((*slog.LogValuer)(nil)).Elem()
+	stringerType      = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	logAsIsTypes      = []reflect.Type{logValuerType, stringerType, textMarshalerType, errorType}
+)
+
+// LogValue returns obj as a slog.Value with DefaultLogOptions
+func LogValue(obj interface{}) slog.Value {
+	return LogValueWithOptions(obj, DefaultLogOptions)
+}
+
+// LogValueWithOptions returns obj as a slog.Value with LogOptions.
+// Structs, or pointers to them, are logged as groups of their exported
+// fields in declaration order, nested structs as nested groups and
+// embedded ones inlined. Fields are named by the log tag, eg
+// `log:"name,omitempty"`, or `log:"-"` to skip them. obj is redacted
+// first like Redact, fields tagged `redact:"drop"` are skipped and the
+// Redact error is logged instead of an invalid obj. Types implementing
+// slog.LogValuer, fmt.Stringer, encoding.TextMarshaler or error, like
+// time.Time, and the other values are logged as they are.
+func LogValueWithOptions(obj interface{}, options LogOptions) slog.Value {
+	redacted, err := Redact(obj)
+	if err != nil {
+		return slog.AnyValue(err)
+	}
+
+	l := &logger{options: options, visited: make(map[visitedPointer]bool)}
+	value, _ := l.value(reflect.ValueOf(redacted), 1)
+
+	return value
+}
+
+// NewLogHandler returns a slog.Handler that logs the struct attribute
+// values with LogValueWithOptions before passing them to handler
+func NewLogHandler(handler slog.Handler, options LogOptions) slog.Handler {
+	return &logHandler{Handler: handler, options: options}
+}
+
+// Handle handles the record with its struct attributes as groups
+func (h *logHandler) Handle(ctx context.Context, r slog.Record) error {
+	record := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		record.AddAttrs(h.attr(a))
+		return true
+	})
+
+	return h.Handler.Handle(ctx, record)
+}
+
+// WithAttrs returns a handler with the attributes, logging the struct
+// ones as groups
+func (h *logHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	converted := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		converted[i] = h.attr(a)
+	}
+
+	return &logHandler{Handler: h.Handler.WithAttrs(converted), options: h.options}
+}
+
+// WithGroup returns a handler that starts the group
+func (h *logHandler) WithGroup(name string) slog.Handler {
+	return &logHandler{Handler: h.Handler.WithGroup(name), options: h.options}
+}
+
+func (h *logHandler) attr(a slog.Attr) slog.Attr {
+	switch a.Value.Kind() {
+	case slog.KindGroup:
+		attrs := a.Value.Group()
+		converted := make([]slog.Attr, len(attrs))
+		for i, attr := range attrs {
+			converted[i] = h.attr(attr)
+		}
+		a.Value = slog.GroupValue(converted...)
+	case slog.KindAny:
+		if t := reflect.TypeOf(a.Value.Any()); t != nil && structType(t) != nil {
+			a.Value = LogValueWithOptions(a.Value.Any(), h.options)
+		}
+	}
+
+	return a
+}
+
+// value returns v as a slog.Value and if it's logged at depth
+func (l *logger) value(v reflect.Value, depth int) (slog.Value, bool) {
+	if !v.IsValid() {
+		return slog.AnyValue(nil), true
+	}
+	for _, t := range logAsIsTypes {
+		if v.Type().Implements(t) {
+			return slog.AnyValue(v.Interface()), true
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Interface:
+		return l.value(v.Elem(), depth)
+	case reflect.Ptr:
+		if v.IsNil() || v.Elem().Kind() != reflect.Struct {
+			break
+		}
+		// Pointers already logged in the current path are omitted
+		key := visitedPointer{v.Pointer(), v.Type()}
+		if l.visited[key] {
+			return slog.Value{}, false
+		}
+		l.visited[key] = true
+		defer delete(l.visited, key)
+		return l.value(v.Elem(), depth)
+	case reflect.Struct:
+		if isOptionalType(v.Type()) {
+			value, _ := v.Interface().(optional).optionalValue()
+			return l.value(reflect.ValueOf(value), depth)
+		}
+		if l.options.MaxDepth > 0 && depth > l.options.MaxDepth {
+			return slog.Value{}, false
+		}
+		return l.group(v, depth), true
+	}
+
+	return slog.AnyValue(v.Interface()), true
+}
+
+func (l *logger) group(v reflect.Value, depth int) slog.Value {
+	t := v.Type()
+	attrs := make([]slog.Attr, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !isExportableField(field) {
+			continue
+		}
+		name, options := parseTagValue(field.Tag.Get("log"))
+		if action, _ := redactAction(field, field.Name); name == "-" || action == redactDrop {
+			continue
+		}
+		fieldValue := v.Field(i)
+		if (l.options.OmitZero || hasOption(options, "omitempty")) && fieldValue.IsZero() {
+			continue
+		}
+
+		// Embedded structs are inlined at the same depth
+		fieldDepth := depth + 1
+		if field.Anonymous {
+			fieldDepth = depth
+		}
+		value, ok := l.value(fieldValue, fieldDepth)
+		if !ok {
+			continue
+		}
+		if len(name) == 0 {
+			name = field.Name
+			if field.Anonymous && value.Kind() == slog.KindGroup {
+				name = ""
+			}
+		}
+		attrs = append(attrs, slog.Attr{Key: name, Value: value})
+	}
+
+	return slog.GroupValue(attrs...)
+}
@@ -0,0 +1,202 @@
+package reflectme
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TestLogAddress struct {
+	City string `log:"city"`
+	Zip  string `sensitive:"true"`
+}
+
+type TestLogBase struct {
+	ID int `log:"id"`
+}
+
+type TestLogUser struct {
+	TestLogBase
+	Name    string          `log:"name"`
+	Address *TestLogAddress `log:"address,omitempty"`
+	Manager *TestLogUser    `log:"manager,omitempty"`
+}
+
+func newTestLogger(handler func(slog.Handler) slog.Handler) (*slog.Logger, *bytes.Buffer) {
+	var buf bytes.Buffer
+	h := slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && (a.Key == slog.TimeKey || a.Key == slog.LevelKey) {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+
+	return slog.New(handler(h)), &buf
+}
+
+func logValueJSON(value slog.Value) string {
+	logger, buf := newTestLogger(func(h slog.Handler) slog.Handler { return h })
+	logger.Info("msg", "value", value)
+
+	return buf.String()
+}
+
+func TestLogValue_on_struct(t *testing.T) {
+	address := &TestLogAddress{City: "city", Zip: "12345"}
+
+	assert.JSONEq(t, `{"msg":"msg","value":{"city":"city","Zip":"****"}}`, logValueJSON(LogValue(address)))
+	assert.Equal(t, "12345", address.Zip)
+}
+
+func TestLogValue_on_tags(t *testing.T) {
+	obj := struct {
+		Name     string `log:"name"`
+		Email    string `log:"email,omitempty"`
+		Phone    string `log:",omitempty"`
+		Internal string `log:"-"`
+		secret   string
+	}{Name: "name", Internal: "internal", secret: "secret"}
+
+	assert.JSONEq(t, `{"msg":"msg","value":{"name":"name"}}`, logValueJSON(LogValue(obj)))
+}
+
+func TestLogValue_on_redacted_fields(t *testing.T) {
+	obj := struct {
+		Password string `redact:"drop"`
+		Token    string `redact:"hash"`
+	}{Password: "password", Token: "token"}
+
+	assert.JSONEq(t, `{"msg":"msg","value":{
+		"Token":"sha256:3c469e9d6c5875d37a43f353d4f88e61fcf812c66eee3457465a40b0da4153e0"
+	}}`, logValueJSON(LogValue(obj)))
+}
+
+func TestLogValue_on_embedded_structs(t *testing.T) {
+	user := TestLogUser{TestLogBase: TestLogBase{ID: 1}, Name: "name"}
+
+	assert.JSONEq(t, `{"msg":"msg","value":{"id":1,"name":"name"}}`, logValueJSON(LogValue(user)))
+}
+
+func TestLogValue_on_nested_structs(t *testing.T) {
+	obj := struct {
+		Address *TestLogAddress
+		Any     interface{}
+	}{Address: &TestLogAddress{City: "city"}, Any: &TestLogAddress{City: "any"}}
+
+	assert.JSONEq(t, `{"msg":"msg","value":{
+		"Address":{"city":"city","Zip":""},
+		"Any":{"city":"any","Zip":""}
+	}}`, logValueJSON(LogValue(obj)))
+}
+
+func TestLogValue_on_collections(t *testing.T) {
+	obj := struct {
+		Tags   []string
+		Labels map[string]int
+	}{Tags: []string{"a"}, Labels: map[string]int{"a": 1}}
+
+	assert.JSONEq(t, `{"msg":"msg","value":{"Tags":["a"],"Labels":{"a":1}}}`, logValueJSON(LogValue(obj)))
+}
+
+func TestLogValue_on_optionals(t *testing.T) {
+	obj := struct {
+		Nickname Optional[string]
+		Age      Optional[int]
+	}{Nickname: Some("nick"), Age: Null[int]()}
+
+	assert.JSONEq(t, `{"msg":"msg","value":{"Nickname":"nick","Age":null}}`, logValueJSON(LogValue(obj)))
+}
+
+func TestLogValue_on_values_logged_as_they_are(t *testing.T) {
+	obj := struct {
+		CreatedAt time.Time
+		Err       error
+	}{CreatedAt: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), Err: errors.New("failure")}
+
+	assert.JSONEq(t, `{"msg":"msg","value":{
+		"CreatedAt":"2020-01-01T00:00:00Z",
+		"Err":"failure"
+	}}`, logValueJSON(LogValue(obj)))
+}
+
+func TestLogValue_on_pointer_cycles(t *testing.T) {
+	user := &TestLogUser{Name: "name"}
+	user.Manager = user
+
+	assert.JSONEq(t, `{"msg":"msg","value":{"id":0,"name":"name"}}`, logValueJSON(LogValue(user)))
+}
+
+func TestLogValue_on_values(t *testing.T) {
+	assert.Equal(t, slog.IntValue(1), LogValue(1))
+	assert.Equal(t, slog.AnyValue(nil), LogValue(nil))
+	assert.Equal(t, slog.AnyValue((*TestLogAddress)(nil)), LogValue((*TestLogAddress)(nil)))
+}
+
+func TestLogValue_on_redact_errors(t *testing.T) {
+	value := LogValue(struct {
+		Secret string `redact:"erase"`
+	}{})
+
+	assert.EqualError(t, value.Any().(error), "Invalid redact action: erase in field Secret")
+}
+
+func TestLogValueWithOptions_with_max_depth(t *testing.T) {
+	user := &TestLogUser{
+		Name:    "name",
+		Address: &TestLogAddress{City: "city"},
+		Manager: &TestLogUser{Name: "manager", Address: &TestLogAddress{City: "city"}},
+	}
+
+	assert.JSONEq(t, `{"msg":"msg","value":{
+		"id":0,
+		"name":"name",
+		"address":{"city":"city","Zip":""},
+		"manager":{"id":0,"name":"manager"}
+	}}`, logValueJSON(LogValueWithOptions(user, LogOptions{MaxDepth: 2})))
+}
+
+func TestLogValueWithOptions_with_omit_zero(t *testing.T) {
+	user := TestLogUser{Name: "name", Address: &TestLogAddress{City: "city"}}
+
+	assert.JSONEq(t, `{"msg":"msg","value":{
+		"name":"name",
+		"address":{"city":"city"}
+	}}`, logValueJSON(LogValueWithOptions(user, LogOptions{OmitZero: true})))
+}
+
+func TestNewLogHandler_on_struct_attrs(t *testing.T) {
+	logger, buf := newTestLogger(func(h slog.Handler) slog.Handler {
+		return NewLogHandler(h, DefaultLogOptions)
+	})
+
+	logger.Info("msg", "address", TestLogAddress{City: "city", Zip: "12345"}, "count", 1)
+	assert.JSONEq(t, `{"msg":"msg","address":{"city":"city","Zip":"****"},"count":1}`, buf.String())
+}
+
+func TestNewLogHandler_on_groups(t *testing.T) {
+	logger, buf := newTestLogger(func(h slog.Handler) slog.Handler {
+		return NewLogHandler(h, DefaultLogOptions)
+	})
+
+	logger.WithGroup("group").Info("msg", slog.Group("nested", "address", &TestLogAddress{City: "city"}))
+	assert.JSONEq(t, `{"msg":"msg","group":{"nested":{"address":{"city":"city","Zip":""}}}}`, buf.String())
+}
+
+func TestNewLogHandler_with_attrs(t *testing.T) {
+	logger, buf := newTestLogger(func(h slog.Handler) slog.Handler {
+		return NewLogHandler(h, LogOptions{MaxDepth: 1})
+	})
+	user := TestLogUser{Name: "name", Address: &TestLogAddress{City: "city"}}
+
+	logger.With("user", user).Info("msg", "created_at", time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.JSONEq(t, `{"msg":"msg",
+		"user":{"id":0,"name":"name"},
+		"created_at":"2020-01-01T00:00:00Z"
+	}`, buf.String())
+}
@@ -0,0 +1,388 @@
+package reflectme
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+type (
+	// Mapper maps structs between types with registered profiles
+	Mapper struct {
+		profiles map[mapperKey]*Profile
+		errs     []error
+	}
+
+	// Profile describes how the fields of a "to" struct type are mapped
+	// from a "from" struct type. Fields not configured are mapped from the
+	// "from" field with the same name.
+	Profile struct {
+		mapper  *Mapper
+		from    reflect.Type
+		to      reflect.Type
+		members map[string]mapperMember
+		err     error
+	}
+
+	mapperKey struct {
+		from reflect.Type
+		to   reflect.Type
+	}
+
+	mapperMember struct {
+		path    string
+		compute reflect.Value
+		ignore  bool
+	}
+)
+
+// NewMapper returns a Mapper without profiles
+func NewMapper() *Mapper {
+	return &Mapper{profiles: make(map[mapperKey]*Profile)}
+}
+
+// CreateMap registers the profile mapping the from type into the to type,
+// replacing any previous one. from and to can whether be a structure or
+// pointer to structure, eg CreateMap(Model{}, (*DTO)(nil)).
+func (m *Mapper) CreateMap(from interface{}, to interface{}) *Profile {
+	fromType, toType := structType(reflect.TypeOf(from)), structType(reflect.TypeOf(to))
+	p := &Profile{
+		mapper:  m,
+		from:    fromType,
+		to:      toType,
+		members: make(map[string]mapperMember),
+	}
+	if fromType == nil || toType == nil {
+		p.err = fmt.Errorf("Cannot use CreateMap on a non-struct type (%v to %v)", reflect.TypeOf(from), reflect.TypeOf(to))
+		m.errs = append(m.errs, p.err)
+		return p
+	}
+	m.profiles[mapperKey{fromType, toType}] = p
+
+	return p
+}
+
+// ForMember maps the to field from the path of the from value. The path
+// is resolved like GetField, eg "Address.City", "Items[0]" or "Total()".
+func (p *Profile) ForMember(field string, path string) *Profile {
+	p.members[field] = mapperMember{path: path}
+	return p
+}
+
+// Compute maps the to field from the result of fn, a function taking
+// the from value, or a pointer to it, and returning the field value and
+// an optional error
+func (p *Profile) Compute(field string, fn interface{}) *Profile {
+	p.members[field] = mapperMember{compute: reflect.ValueOf(fn)}
+	return p
+}
+
+// Ignore leaves the provided to fields unmapped
+func (p *Profile) Ignore(fields ...string) *Profile {
+	for _, field := range fields {
+		p.members[field] = mapperMember{ignore: true}
+	}
+	return p
+}
+
+// ReverseMap registers and returns the profile mapping the to type back
+// into the from type. Members mapped from a top level field are reversed,
+// while computed members, nested paths and ignored fields can't be, so
+// they must be configured in the returned profile.
+func (p *Profile) ReverseMap() *Profile {
+	if p.err != nil {
+		return p
+	}
+
+	reverse := p.mapper.CreateMap(reflect.Zero(p.to).Interface(), reflect.Zero(p.from).Interface())
+	for field, member := range p.members {
+		if len(member.path) > 0 && !strings.ContainsAny(member.path, ".[(") {
+			reverse.ForMember(member.path, field)
+		}
+	}
+
+	return reverse
+}
+
+// Map maps from into the struct pointed by to with the profile registered
+// for their types. Nested structs, and slices and maps of them, are mapped
+// with their own profiles. from can whether be a structure or pointer to
+// structure.
+func (m *Mapper) Map(from interface{}, to interface{}) error {
+	if to == nil || !isPointer(to) {
+		return errors.New("To must be a pointer")
+	}
+	fromValue := reflect.ValueOf(from)
+	if from == nil || (fromValue.Kind() == reflect.Ptr && fromValue.IsNil()) {
+		return errors.New("Cannot use Map on a nil interface")
+	}
+
+	p := m.profile(fromValue.Type(), reflect.TypeOf(to))
+	if p == nil {
+		return fmt.Errorf("No mapping from %v to %v", fromValue.Type(), reflect.TypeOf(to))
+	}
+
+	return p.apply(reflect.Indirect(fromValue), reflect.ValueOf(to).Elem())
+}
+
+// Validate checks that every exported field of every profile to type is
+// mapped from a field, path or function of a compatible type, or ignored.
+// Nested structs of different types need their own profile.
+func (m *Mapper) Validate() error {
+	var profiles []*Profile
+	for _, p := range m.profiles {
+		profiles = append(profiles, p)
+	}
+	sort.Slice(profiles, func(i, j int) bool {
+		return profiles[i].String() < profiles[j].String()
+	})
+
+	var messages []string
+	for _, err := range m.errs {
+		messages = append(messages, err.Error())
+	}
+	for _, p := range profiles {
+		for _, err := range p.validate() {
+			messages = append(messages, fmt.Sprintf("%v: %v", p, err))
+		}
+	}
+	if len(messages) > 0 {
+		return fmt.Errorf("Invalid mappings: %s", strings.Join(messages, "; "))
+	}
+
+	return nil
+}
+
+// String returns the profile types, eg "Model -> DTO"
+func (p *Profile) String() string {
+	return fmt.Sprintf("%v -> %v", p.from, p.to)
+}
+
+func (m *Mapper) profile(from reflect.Type, to reflect.Type) *Profile {
+	fromType, toType := structType(from), structType(to)
+	if fromType == nil || toType == nil {
+		return nil
+	}
+
+	return m.profiles[mapperKey{fromType, toType}]
+}
+
+func (p *Profile) validate() []error {
+	var errs []error
+	for field := range p.members {
+		if f, ok := p.to.FieldByName(field); !ok || !isExportableField(f) {
+			errs = append(errs, fmt.Errorf("No such field: %s in obj", field))
+		}
+	}
+	for i := 0; i < p.to.NumField(); i++ {
+		field := p.to.Field(i)
+		if !isExportableField(field) {
+			continue
+		}
+		if err := p.validateField(field); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	sort.Slice(errs, func(i, j int) bool {
+		return errs[i].Error() < errs[j].Error()
+	})
+
+	return errs
+}
+
+func (p *Profile) validateField(field reflect.StructField) error {
+	member, configured := p.members[field.Name]
+	if member.ignore {
+		return nil
+	}
+
+	var fromType reflect.Type
+	if member.compute.IsValid() {
+		fnType := member.compute.Type()
+		if !p.isComputeFunc(fnType) {
+			return fmt.Errorf("Compute function for field %s must take %v and return a value and an optional error", field.Name, p.from)
+		}
+		fromType = fnType.Out(0)
+	} else {
+		path := field.Name
+		if configured {
+			path = member.path
+		}
+		t, err := resolveTypePath(p.from, path, DefaultPathOptions)
+		if err != nil {
+			if !configured {
+				return fmt.Errorf("Unmapped field: %s", field.Name)
+			}
+			return fmt.Errorf("Invalid path %s for field %s: %v", path, field.Name, err)
+		}
+		fromType = t
+	}
+
+	if !p.mapper.isMappableType(fromType, field.Type) {
+		return fmt.Errorf("Cannot map %v into field %s (%v)", fromType, field.Name, field.Type)
+	}
+
+	return nil
+}
+
+func (p *Profile) isComputeFunc(t reflect.Type) bool {
+	if t.Kind() != reflect.Func || t.NumIn() != 1 || (t.In(0) != p.from && t.In(0) != reflect.PtrTo(p.from)) {
+		return false
+	}
+	numOut := t.NumOut()
+	return numOut == 1 || (numOut == 2 && t.Out(1) == errorType)
+}
+
+// isMappableType indicates if a from value can be mapped into a t value.
+// Different struct types need a profile.
+func (m *Mapper) isMappableType(from reflect.Type, t reflect.Type) bool {
+	fromKind := from.Kind()
+	switch {
+	case from.AssignableTo(t), m.profile(from, t) != nil:
+		return true
+	case (fromKind == reflect.Slice || fromKind == reflect.Array) && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array):
+		return m.isMappableType(from.Elem(), t.Elem())
+	case fromKind == reflect.Map && t.Kind() == reflect.Map:
+		return isConvertibleType(from.Key(), t.Key()) && m.isMappableType(from.Elem(), t.Elem())
+	case structType(from) != nil && structType(t) != nil:
+		return false
+	}
+
+	return isCopyableType(from, t)
+}
+
+func (p *Profile) apply(from reflect.Value, to reflect.Value) error {
+	for i := 0; i < p.to.NumField(); i++ {
+		field := p.to.Field(i)
+		member, configured := p.members[field.Name]
+		if !isExportableField(field) || member.ignore {
+			continue
+		}
+
+		var v reflect.Value
+		var err error
+		if member.compute.IsValid() {
+			v, err = p.compute(member.compute, from)
+		} else {
+			path := field.Name
+			if configured {
+				path = member.path
+			}
+			v, err = p.source(from, path)
+		}
+		if err == nil && v.IsValid() {
+			v, err = p.mapper.mapValue(v, field.Type)
+		}
+		if err != nil {
+			return fmt.Errorf("Cannot map field %s: %v", field.Name, err)
+		}
+		if v.IsValid() {
+			to.Field(i).Set(v)
+		}
+	}
+
+	return nil
+}
+
+// source returns the path value of from, or an invalid value when it
+// isn't found. Paths through nil pointers, missing map keys or out of
+// range indexes don't resolve and leave the field untouched, while method
+// errors are returned.
+func (p *Profile) source(from reflect.Value, path string) (reflect.Value, error) {
+	if _, err := resolveTypePath(p.from, path, DefaultPathOptions); err != nil {
+		return reflect.Value{}, err
+	}
+	v, err := getInnerField(from.Interface(), path)
+	if err != nil && strings.Contains(path, "()") {
+		return reflect.Value{}, err
+	}
+
+	return v, nil
+}
+
+func (p *Profile) compute(fn reflect.Value, from reflect.Value) (reflect.Value, error) {
+	if !p.isComputeFunc(fn.Type()) {
+		return reflect.Value{}, fmt.Errorf("Compute function must take %v and return a value and an optional error", p.from)
+	}
+
+	arg := reflect.New(p.from)
+	arg.Elem().Set(from)
+	if fn.Type().In(0) == p.from {
+		arg = arg.Elem()
+	}
+	out := fn.Call([]reflect.Value{arg})
+	if len(out) == 2 && !out[1].IsNil() {
+		return reflect.Value{}, out[1].Interface().(error)
+	}
+
+	return out[0], nil
+}
+
+// mapValue returns v as a new t value, mapping structs with their profile
+// and collections element by element
+func (m *Mapper) mapValue(v reflect.Value, t reflect.Type) (reflect.Value, error) {
+	if v.Kind() == reflect.Interface && !v.IsNil() {
+		v = v.Elem()
+	}
+	kind := v.Kind()
+
+	if p := m.profile(v.Type(), t); p != nil {
+		if kind == reflect.Ptr && v.IsNil() {
+			return reflect.Zero(t), nil
+		}
+		to := reflect.New(p.to)
+		if err := p.apply(reflect.Indirect(v), to.Elem()); err != nil {
+			return reflect.Value{}, err
+		}
+		if t.Kind() == reflect.Ptr {
+			return to, nil
+		}
+		return to.Elem(), nil
+	}
+
+	switch {
+	case v.Type().AssignableTo(t):
+		return v, nil
+	case (kind == reflect.Slice || kind == reflect.Array) && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array):
+		if kind == reflect.Slice && v.IsNil() {
+			return reflect.Zero(t), nil
+		}
+		to := reflect.New(t).Elem()
+		n := v.Len()
+		if t.Kind() == reflect.Slice {
+			to.Set(reflect.MakeSlice(t, n, n))
+		} else if t.Len() < n {
+			n = t.Len()
+		}
+		for i := 0; i < n; i++ {
+			elem, err := m.mapValue(v.Index(i), t.Elem())
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("Cannot map element %d: %v", i, err)
+			}
+			to.Index(i).Set(elem)
+		}
+		return to, nil
+	case kind == reflect.Map && t.Kind() == reflect.Map:
+		if v.IsNil() {
+			return reflect.Zero(t), nil
+		}
+		to := reflect.MakeMapWithSize(t, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			key, err := convertValue(iter.Key().Interface(), t.Key())
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("Cannot map key %v: %v", iter.Key(), err)
+			}
+			elem, err := m.mapValue(iter.Value(), t.Elem())
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("Cannot map key %v: %v", iter.Key(), err)
+			}
+			to.SetMapIndex(key, elem)
+		}
+		return to, nil
+	}
+
+	return copyValue(v, t, CopyOptions{CopyZeroValues: true})
+}
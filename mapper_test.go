@@ -0,0 +1,391 @@
+package reflectme
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TestMapperOrder struct {
+	ID    int
+	Total float64
+}
+
+type TestMapperOrderDTO struct {
+	ID     int
+	Amount float64
+}
+
+type TestMapperUser struct {
+	ID        int
+	FirstName string
+	LastName  string
+	Password  string
+	Address   *NestedStruct
+	Orders    []TestMapperOrder
+	ByKey     map[string]TestMapperOrder
+	Any       interface{}
+}
+
+type TestMapperUserDTO struct {
+	ID       int
+	FullName string
+	City     string
+	Orders   []TestMapperOrderDTO
+	ByKey    map[string]*TestMapperOrderDTO
+	Any      interface{}
+	Internal string
+	internal string
+}
+
+type TestMapperInvalidDTO struct {
+	ID       string
+	Name     string
+	Address  TestDummyOnlyStruct
+	Computed string
+	Other    string
+	Failed   string
+}
+
+var testMapperInvalidFields = []string{"ID", "Name", "Address", "Computed", "Other", "Failed"}
+
+func TestMapper_Map_on_same_field_names(t *testing.T) {
+	m := NewMapper()
+	m.CreateMap(TestMapperOrder{}, TestMapperOrderDTO{}).Ignore("Amount")
+
+	dto := TestMapperOrderDTO{Amount: 1}
+	err := m.Map(TestMapperOrder{ID: 1, Total: 2}, &dto)
+	assert.NoError(t, err)
+	assert.Equal(t, TestMapperOrderDTO{ID: 1, Amount: 1}, dto)
+}
+
+func TestMapper_Map_with_for_member(t *testing.T) {
+	m := NewMapper()
+	m.CreateMap(TestMapperOrder{}, TestMapperOrderDTO{}).ForMember("Amount", "Total")
+
+	dto := TestMapperOrderDTO{}
+	err := m.Map(TestMapperOrder{ID: 1, Total: 2}, &dto)
+	assert.NoError(t, err)
+	assert.Equal(t, TestMapperOrderDTO{ID: 1, Amount: 2}, dto)
+}
+
+func TestMapper_Map_with_nested_paths(t *testing.T) {
+	m := NewMapper()
+	m.CreateMap(TestMapperUser{}, TestMapperUserDTO{}).ForMember("City", "Address.Dummy").Ignore("FullName", "Internal")
+
+	dto := TestMapperUserDTO{}
+	err := m.Map(TestMapperUser{Address: &NestedStruct{Dummy: "Lisbon"}}, &dto)
+	assert.NoError(t, err)
+	assert.Equal(t, "Lisbon", dto.City)
+}
+
+func TestMapper_Map_with_compute(t *testing.T) {
+	m := NewMapper()
+	m.CreateMap(TestMapperUser{}, TestMapperUserDTO{}).
+		Compute("FullName", func(u TestMapperUser) string {
+			return u.FirstName + " " + u.LastName
+		}).
+		Ignore("City", "Internal")
+
+	dto := TestMapperUserDTO{}
+	err := m.Map(TestMapperUser{FirstName: "John", LastName: "Doe"}, &dto)
+	assert.NoError(t, err)
+	assert.Equal(t, "John Doe", dto.FullName)
+}
+
+func TestMapper_Map_with_compute_on_pointers(t *testing.T) {
+	m := NewMapper()
+	m.CreateMap(TestMapperUser{}, TestMapperUserDTO{}).
+		Compute("FullName", func(u *TestMapperUser) (string, error) {
+			return u.FirstName, nil
+		}).
+		Ignore("City", "Internal")
+
+	dto := TestMapperUserDTO{}
+	err := m.Map(&TestMapperUser{FirstName: "John"}, &dto)
+	assert.NoError(t, err)
+	assert.Equal(t, "John", dto.FullName)
+}
+
+func TestMapper_Map_with_ignore(t *testing.T) {
+	m := NewMapper()
+	m.CreateMap(TestMapperUser{}, TestMapperUserDTO{}).Ignore("ID", "FullName", "City", "Internal")
+
+	dto := TestMapperUserDTO{Internal: "internal", internal: "internal"}
+	err := m.Map(TestMapperUser{ID: 1}, &dto)
+	assert.NoError(t, err)
+	assert.Equal(t, TestMapperUserDTO{Internal: "internal", internal: "internal"}, dto)
+}
+
+func TestMapper_Map_on_pointer_profiles(t *testing.T) {
+	m := NewMapper()
+	m.CreateMap((*TestMapperOrder)(nil), (*TestMapperOrderDTO)(nil)).ForMember("Amount", "Total")
+
+	dto := TestMapperOrderDTO{}
+	err := m.Map(&TestMapperOrder{ID: 1, Total: 2}, &dto)
+	assert.NoError(t, err)
+	assert.Equal(t, TestMapperOrderDTO{ID: 1, Amount: 2}, dto)
+}
+
+func TestMapper_Map_on_reverse_map(t *testing.T) {
+	m := NewMapper()
+	m.CreateMap(TestMapperOrder{}, TestMapperOrderDTO{}).ForMember("Amount", "Total").ReverseMap()
+
+	order := TestMapperOrder{}
+	err := m.Map(TestMapperOrderDTO{ID: 1, Amount: 2}, &order)
+	assert.NoError(t, err)
+	assert.Equal(t, TestMapperOrder{ID: 1, Total: 2}, order)
+}
+
+func TestMapper_Map_on_nested_slices_and_maps(t *testing.T) {
+	m := NewMapper()
+	m.CreateMap(TestMapperUser{}, TestMapperUserDTO{}).Ignore("FullName", "City", "Internal")
+	m.CreateMap(TestMapperOrder{}, TestMapperOrderDTO{}).ForMember("Amount", "Total")
+
+	dto := TestMapperUserDTO{}
+	err := m.Map(TestMapperUser{
+		Orders: []TestMapperOrder{{ID: 1, Total: 9.5}},
+		ByKey:  map[string]TestMapperOrder{"a": {ID: 2, Total: 1}},
+	}, &dto)
+	assert.NoError(t, err)
+	assert.Equal(t, []TestMapperOrderDTO{{ID: 1, Amount: 9.5}}, dto.Orders)
+	assert.Equal(t, map[string]*TestMapperOrderDTO{"a": {ID: 2, Amount: 1}}, dto.ByKey)
+}
+
+func TestMapper_Map_on_interfaces(t *testing.T) {
+	m := NewMapper()
+	m.CreateMap(TestMapperUser{}, TestMapperUserDTO{}).Ignore("FullName", "City", "Internal")
+
+	dto := TestMapperUserDTO{}
+	err := m.Map(TestMapperUser{Any: TestMapperOrder{ID: 3}}, &dto)
+	assert.NoError(t, err)
+	assert.Equal(t, TestMapperOrder{ID: 3}, dto.Any)
+}
+
+func TestMapper_Map_on_unresolved_paths(t *testing.T) {
+	m := NewMapper()
+	m.CreateMap(TestMapperUser{}, TestMapperUserDTO{}).ForMember("City", "Address.Dummy").Ignore("FullName", "Internal")
+
+	dto := TestMapperUserDTO{City: "city"}
+	err := m.Map(TestMapperUser{}, &dto)
+	assert.NoError(t, err)
+	assert.Equal(t, TestMapperUserDTO{City: "city"}, dto)
+}
+
+func TestMapper_Map_on_replaced_profiles(t *testing.T) {
+	m := NewMapper()
+	m.CreateMap(TestMapperOrder{}, TestMapperOrderDTO{}).Ignore("ID")
+	m.CreateMap(TestMapperOrder{}, TestMapperOrderDTO{}).ForMember("Amount", "Total")
+
+	dto := TestMapperOrderDTO{}
+	err := m.Map(TestMapperOrder{ID: 1, Total: 2}, &dto)
+	assert.NoError(t, err)
+	assert.Equal(t, TestMapperOrderDTO{ID: 1, Amount: 2}, dto)
+}
+
+func TestMapper_Map_on_invalid_args(t *testing.T) {
+	m := NewMapper()
+	m.CreateMap(TestMapperUser{}, TestMapperUserDTO{})
+
+	err := m.Map(TestMapperUser{}, TestMapperUserDTO{})
+	assert.EqualError(t, err, "To must be a pointer")
+
+	err = m.Map(nil, &TestMapperUserDTO{})
+	assert.EqualError(t, err, "Cannot use Map on a nil interface")
+
+	err = m.Map((*TestMapperUser)(nil), &TestMapperUserDTO{})
+	assert.EqualError(t, err, "Cannot use Map on a nil interface")
+}
+
+func TestMapper_Map_on_missing_profiles(t *testing.T) {
+	m := NewMapper()
+	m.CreateMap(TestMapperUser{}, TestMapperUserDTO{})
+
+	err := m.Map(TestMapperUserDTO{}, &TestMapperUser{})
+	assert.EqualError(t, err, "No mapping from reflectme.TestMapperUserDTO to *reflectme.TestMapperUser")
+}
+
+func TestMapper_Map_on_method_errors(t *testing.T) {
+	m := NewMapper()
+	m.CreateMap(TestMethodPathStruct{}, TestMapperInvalidDTO{}).
+		Ignore(testMapperInvalidFields...).
+		ForMember("Name", "Fail()")
+
+	err := m.Map(TestMethodPathStruct{}, &TestMapperInvalidDTO{})
+	assert.EqualError(t, err, "Cannot map field Name: fail")
+}
+
+func TestMapper_Map_on_invalid_paths(t *testing.T) {
+	m := NewMapper()
+	m.CreateMap(TestMethodPathStruct{}, TestMapperInvalidDTO{}).
+		Ignore(testMapperInvalidFields...).
+		ForMember("Name", "Obladioblada")
+
+	err := m.Map(TestMethodPathStruct{}, &TestMapperInvalidDTO{})
+	assert.EqualError(t, err, "Cannot map field Name: No such field: Obladioblada in obj")
+}
+
+func TestMapper_Map_on_compute_errors(t *testing.T) {
+	m := NewMapper()
+	m.CreateMap(TestMethodPathStruct{}, TestMapperInvalidDTO{}).
+		Ignore(testMapperInvalidFields...).
+		Compute("Name", func(s *TestMethodPathStruct) (string, error) {
+			return "", errors.New("compute")
+		})
+
+	err := m.Map(TestMethodPathStruct{}, &TestMapperInvalidDTO{})
+	assert.EqualError(t, err, "Cannot map field Name: compute")
+}
+
+func TestMapper_Map_on_invalid_compute_functions(t *testing.T) {
+	m := NewMapper()
+	m.CreateMap(TestMethodPathStruct{}, TestMapperInvalidDTO{}).
+		Ignore(testMapperInvalidFields...).
+		Compute("Name", func() string { return "" })
+
+	err := m.Map(TestMethodPathStruct{}, &TestMapperInvalidDTO{})
+	assert.EqualError(t, err, "Cannot map field Name: Compute function must take reflectme.TestMethodPathStruct and return a value and an optional error")
+}
+
+func TestMapper_Map_on_type_mismatch(t *testing.T) {
+	m := NewMapper()
+	m.CreateMap(TestMethodPathStruct{}, TestMapperInvalidDTO{}).
+		Ignore(testMapperInvalidFields...).
+		ForMember("ID", "Nested")
+
+	err := m.Map(TestMethodPathStruct{Nested: &NestedStruct{}}, &TestMapperInvalidDTO{})
+	assert.EqualError(t, err, "Cannot map field ID: Provided value type (*reflectme.NestedStruct) didn't match obj field type (string)")
+}
+
+func TestMapper_Map_on_nested_errors(t *testing.T) {
+	m := NewMapper()
+	m.CreateMap(TestMapperUser{}, TestMapperUserDTO{}).Ignore("FullName", "City", "Internal")
+	m.CreateMap(TestMapperOrder{}, TestMapperOrderDTO{}).
+		Compute("Amount", func(o TestMapperOrder) (float64, error) {
+			return 0, errors.New("amount")
+		})
+
+	err := m.Map(TestMapperUser{Orders: []TestMapperOrder{{}}}, &TestMapperUserDTO{})
+	assert.EqualError(t, err, "Cannot map field Orders: Cannot map element 0: Cannot map field Amount: amount")
+
+	err = m.Map(TestMapperUser{ByKey: map[string]TestMapperOrder{"a": {}}}, &TestMapperUserDTO{})
+	assert.EqualError(t, err, "Cannot map field ByKey: Cannot map key a: Cannot map field Amount: amount")
+}
+
+func TestMapper_mapValue_on_arrays(t *testing.T) {
+	m := NewMapper()
+	m.CreateMap(TestMapperOrder{}, TestMapperOrderDTO{}).Ignore("Amount")
+
+	v, err := m.mapValue(reflect.ValueOf([2]TestMapperOrder{{ID: 1}, {ID: 2}}), reflect.TypeOf([1]TestMapperOrderDTO{}))
+	assert.NoError(t, err)
+	assert.Equal(t, [1]TestMapperOrderDTO{{ID: 1}}, v.Interface())
+}
+
+func TestMapper_mapValue_on_nil_values(t *testing.T) {
+	m := NewMapper()
+	m.CreateMap(TestMapperOrder{}, TestMapperOrderDTO{}).Ignore("Amount")
+
+	v, err := m.mapValue(reflect.ValueOf((*TestMapperOrder)(nil)), reflect.TypeOf(&TestMapperOrderDTO{}))
+	assert.NoError(t, err)
+	assert.Nil(t, v.Interface())
+
+	v, err = m.mapValue(reflect.ValueOf(map[int32]TestMapperOrder(nil)), reflect.TypeOf(map[int64]TestMapperOrderDTO{}))
+	assert.NoError(t, err)
+	assert.Nil(t, v.Interface())
+
+	v, err = m.mapValue(reflect.ValueOf([]TestMapperOrder(nil)), reflect.TypeOf([]TestMapperOrderDTO{}))
+	assert.NoError(t, err)
+	assert.Nil(t, v.Interface())
+}
+
+func TestMapper_mapValue_on_invalid_keys(t *testing.T) {
+	_, err := NewMapper().mapValue(reflect.ValueOf(map[string]int{"a": 1}), reflect.TypeOf(map[int]int{}))
+	assert.EqualError(t, err, "Cannot map key a: Provided value type (string) didn't match type (int)")
+}
+
+func TestMapper_Validate(t *testing.T) {
+	m := NewMapper()
+	m.CreateMap(TestMapperUser{}, TestMapperUserDTO{}).
+		Compute("FullName", func(u TestMapperUser) string {
+			return u.FirstName + " " + u.LastName
+		}).
+		ForMember("City", "Address.Dummy").
+		Ignore("Internal")
+	m.CreateMap(TestMapperOrder{}, (*TestMapperOrderDTO)(nil)).
+		ForMember("Amount", "Total").
+		ReverseMap()
+
+	assert.NoError(t, m.Validate())
+}
+
+func TestMapper_Validate_on_invalid_members(t *testing.T) {
+	cases := []struct {
+		field     string
+		configure func(p *Profile)
+		expected  string
+	}{
+		{"ID", nil, "Cannot map int into field ID (string)"},
+		{"Address", nil, "Cannot map *reflectme.NestedStruct into field Address (reflectme.TestDummyOnlyStruct)"},
+		{"Other", nil, "Unmapped field: Other"},
+		{"Name", func(p *Profile) { p.ForMember("Name", "FirstName.Obladioblada") },
+			"Invalid path FirstName.Obladioblada for field Name: Not a struct: Obladioblada in obj"},
+		{"Computed", func(p *Profile) { p.Compute("Computed", func(s string) string { return s }) },
+			"Compute function for field Computed must take reflectme.TestMapperUser and return a value and an optional error"},
+		{"Failed", func(p *Profile) { p.Compute("Failed", func(u *TestMapperUser) (int, error) { return 0, nil }) },
+			"Cannot map int into field Failed (string)"},
+		{"", func(p *Profile) { p.ForMember("Obladioblada", "ID") }, "No such field: Obladioblada in obj"},
+	}
+	for _, c := range cases {
+		m := NewMapper()
+		p := m.CreateMap(TestMapperUser{}, TestMapperInvalidDTO{})
+		for _, field := range testMapperInvalidFields {
+			if field != c.field {
+				p.Ignore(field)
+			}
+		}
+		if c.configure != nil {
+			c.configure(p)
+		}
+		assert.EqualError(t, m.Validate(), "Invalid mappings: reflectme.TestMapperUser -> reflectme.TestMapperInvalidDTO: "+c.expected)
+	}
+}
+
+func TestMapper_Validate_on_multiple_errors(t *testing.T) {
+	m := NewMapper()
+	m.CreateMap(TestMapperUser{}, TestMapperInvalidDTO{}).Ignore("ID", "Address", "Computed", "Failed")
+
+	assert.EqualError(t, m.Validate(), "Invalid mappings: "+
+		"reflectme.TestMapperUser -> reflectme.TestMapperInvalidDTO: Unmapped field: Name; "+
+		"reflectme.TestMapperUser -> reflectme.TestMapperInvalidDTO: Unmapped field: Other")
+}
+
+func TestMapper_Validate_on_non_struct_types(t *testing.T) {
+	m := NewMapper()
+	m.CreateMap(1, TestMapperUserDTO{}).ReverseMap()
+
+	assert.EqualError(t, m.Validate(), "Invalid mappings: Cannot use CreateMap on a non-struct type (int to reflectme.TestMapperUserDTO)")
+}
+
+func TestMapper_isMappableType(t *testing.T) {
+	m := NewMapper()
+	m.CreateMap(TestMapperOrder{}, TestMapperOrderDTO{})
+
+	cases := []struct {
+		from     interface{}
+		to       interface{}
+		mappable bool
+	}{
+		{[]TestMapperOrder{}, [2]*TestMapperOrderDTO{}, true},
+		{map[int32]TestMapperOrder{}, map[int64]TestMapperOrderDTO{}, true},
+		{map[string]TestMapperOrder{}, map[int]TestMapperOrderDTO{}, false},
+		{[]TestMapperUser{}, []TestMapperOrderDTO{}, false},
+		{map[string]interface{}{}, TestMapperOrderDTO{}, true},
+		{1, "", false},
+	}
+	for _, c := range cases {
+		from, to := reflect.TypeOf(c.from), reflect.TypeOf(c.to)
+		assert.Equal(t, c.mappable, m.isMappableType(from, to), "%v to %v", from, to)
+	}
+}
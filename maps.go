@@ -0,0 +1,201 @@
+package reflectme
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+var mapType = reflect.TypeOf(map[string]interface{}{})
+
+// isStringMap indicates if obj is a map with string keys
+func isStringMap(obj interface{}) bool {
+	t := reflect.TypeOf(obj)
+	return t != nil && t.Kind() == reflect.Map && t.Key().Kind() == reflect.String
+}
+
+// isMapPointer indicates if obj is a *map[string]interface{}
+func isMapPointer(obj interface{}) bool {
+	t := reflect.TypeOf(obj)
+	return t != nil && t.Kind() == reflect.Ptr && t.Elem() == mapType
+}
+
+// copyFromMap copies the from map entries to the to struct or map. Keys
+// can be dotted field names and nested maps are copied into the
+// matching nested structs.
+func copyFromMap(from interface{}, to interface{}, options CopyOptions) error {
+	fromValue := reflect.ValueOf(from)
+	keys := fromValue.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].String() < keys[j].String()
+	})
+
+	if isMapPointer(to) {
+		copyMapToMap(fromValue, keys, mapPointerValue(to), "", options)
+		return nil
+	}
+
+	if structType(reflect.TypeOf(to)) == nil {
+		return errors.New("To must be a pointer to struct or map")
+	}
+	return copyMapEntries(fromValue, keys, to, "", options)
+}
+
+func copyMapEntries(fromValue reflect.Value, keys []reflect.Value, to interface{}, parent string, options CopyOptions) error {
+	toType := reflect.TypeOf(to)
+	for _, key := range keys {
+		path := parent + key.String()
+		v := fromValue.MapIndex(key).Interface()
+
+		fieldType, typeErr := resolveTypePath(toType, path, PathOptions{})
+		if nested, ok := v.(map[string]interface{}); ok && typeErr == nil && structType(fieldType) != nil {
+			nestedValue := reflect.ValueOf(nested)
+			nestedKeys := nestedValue.MapKeys()
+			sort.Slice(nestedKeys, func(i, j int) bool {
+				return nestedKeys[i].String() < nestedKeys[j].String()
+			})
+			if err := copyMapEntries(nestedValue, nestedKeys, to, path+".", options); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !options.selects(path, false) {
+			continue
+		}
+		if !options.CopyZeroValues && IsZeroValue(v) {
+			options.report.skippedZero(path)
+			continue
+		}
+		// Map values are loosely typed, eg numbers decoded from JSON. Numbers
+		// that don't keep their value aren't converted and fail to be set.
+		if typeErr == nil {
+			if converted, err := convertValue(v, fieldType); err == nil {
+				v = converted.Interface()
+			}
+		}
+		if err := SetField(to, path, v); err != nil {
+			options.report.failed(to, path)
+			if !options.IgnoreNotFoundFields {
+				return err
+			}
+			continue
+		}
+		options.report.copied(path)
+	}
+
+	return nil
+}
+
+// copyMapToMap copies the from map entries to m. Nested maps are walked,
+// so Include and Exclude match their entries paths, and copied.
+func copyMapToMap(fromValue reflect.Value, keys []reflect.Value, m map[string]interface{}, parent string, options CopyOptions) {
+	for _, key := range keys {
+		path := parent + key.String()
+		v := fromValue.MapIndex(key).Interface()
+
+		if nested, ok := v.(map[string]interface{}); ok && len(nested) > 0 {
+			nestedValue := reflect.ValueOf(nested)
+			nestedKeys := nestedValue.MapKeys()
+			sort.Slice(nestedKeys, func(i, j int) bool {
+				return nestedKeys[i].String() < nestedKeys[j].String()
+			})
+			copyMapToMap(nestedValue, nestedKeys, m, path+".", options)
+			continue
+		}
+
+		if !options.selects(path, false) {
+			continue
+		}
+		if !options.CopyZeroValues && IsZeroValue(v) {
+			options.report.skippedZero(path)
+			continue
+		}
+		setMapPath(m, path, cloneMapValue(v), options.FlattenMaps)
+		options.report.copied(path)
+	}
+}
+
+// cloneMapValue copies the maps and slices of a loosely typed map value,
+// eg decoded from JSON, so the copy doesn't share them with the source
+func cloneMapValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if v == nil {
+			return v
+		}
+		m := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			m[key] = cloneMapValue(value)
+		}
+		return m
+	case []interface{}:
+		if v == nil {
+			return v
+		}
+		s := make([]interface{}, len(v))
+		for i, value := range v {
+			s[i] = cloneMapValue(value)
+		}
+		return s
+	}
+
+	return v
+}
+
+// copyToMap copies the from struct leaf fields to the to map
+func copyToMap(from interface{}, to interface{}, options CopyOptions) error {
+	fields, err := FieldsWithOptions(from, FieldsOptions{LeavesOnly: true})
+	if err != nil {
+		return err
+	}
+
+	m := mapPointerValue(to)
+	for _, field := range fields {
+		if !options.selects(field.Path, false) {
+			continue
+		}
+		// Null Optional values aren't zero, so they are copied as nil
+		value := field.Value.Interface()
+		v, present := unwrapOptional(value)
+		if !present || (!options.CopyZeroValues && IsZeroValue(value)) {
+			options.report.skippedZero(field.Path)
+			continue
+		}
+		setMapPath(m, field.Path, v, options.FlattenMaps)
+		options.report.copied(field.Path)
+	}
+
+	return nil
+}
+
+// mapPointerValue returns the map pointed by to, allocating it when nil
+func mapPointerValue(to interface{}) map[string]interface{} {
+	p := to.(*map[string]interface{})
+	if *p == nil {
+		*p = make(map[string]interface{})
+	}
+
+	return *p
+}
+
+// setMapPath sets the dotted path in m. Unless flatten is true, nested
+// maps are created for each path segment.
+func setMapPath(m map[string]interface{}, path string, v interface{}, flatten bool) {
+	if flatten {
+		m[path] = v
+		return
+	}
+
+	segments := strings.Split(path, ".")
+	for _, segment := range segments[:len(segments)-1] {
+		nested, ok := m[segment].(map[string]interface{})
+		if !ok {
+			nested = make(map[string]interface{})
+			m[segment] = nested
+		}
+		m = nested
+	}
+	m[segments[len(segments)-1]] = v
+}
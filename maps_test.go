@@ -0,0 +1,237 @@
+package reflectme
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TestMapsStruct struct {
+	Dummy     string
+	Yummy     int
+	Score     float64
+	CreatedAt time.Time
+	Labels    map[string]interface{}
+	Nested    *NestedStruct
+	Inner     TestNestedStruct
+}
+
+func TestCopy_from_nested_map(t *testing.T) {
+	from := map[string]interface{}{
+		"Dummy":  "test",
+		"Yummy":  float64(10),
+		"Labels": map[string]interface{}{"a": 1},
+		"Nested": map[string]interface{}{
+			"Dummy": "nested",
+			"Yummy": 1,
+		},
+		"Inner": map[string]interface{}{
+			"Nested": map[string]interface{}{"Yummy": 3},
+		},
+	}
+	to := TestMapsStruct{Score: 1.5}
+
+	err := CopyWithOptions(from, &to, CopyOptions{CopyZeroValues: true})
+	assert.NoError(t, err)
+	assert.Equal(t, TestMapsStruct{
+		Dummy:  "test",
+		Yummy:  10,
+		Score:  1.5,
+		Labels: map[string]interface{}{"a": 1},
+		Nested: &NestedStruct{Dummy: "nested", Yummy: 1},
+		Inner:  TestNestedStruct{Nested: NestedStruct{Yummy: 3}},
+	}, to)
+}
+
+func TestCopy_from_flat_map(t *testing.T) {
+	from := map[string]interface{}{
+		"Nested.Yummy":       int8(2),
+		"Inner.Nested.Dummy": "inner",
+		"Dummy":              "",
+	}
+	to := TestMapsStruct{Dummy: "keep"}
+
+	err := CopyWithOptions(from, &to, CopyOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "keep", to.Dummy)
+	assert.Equal(t, &NestedStruct{Yummy: 2}, to.Nested)
+	assert.Equal(t, "inner", to.Inner.Nested.Dummy)
+}
+
+func TestCopy_from_map_not_ignoring_not_found_fields(t *testing.T) {
+	to := TestMapsStruct{}
+
+	err := CopyWithOptions(map[string]interface{}{"Obladioblada": 1}, &to, CopyOptions{})
+	assert.Error(t, err)
+
+	err = CopyWithOptions(map[string]interface{}{"Dummy": 1}, &to, CopyOptions{})
+	assert.Error(t, err)
+
+	err = CopyWithOptions(map[string]interface{}{"Inner": map[string]interface{}{"Obladioblada": 1}}, &to, CopyOptions{})
+	assert.Error(t, err)
+
+	err = CopyWithOptions(map[string]interface{}{"Obladioblada": 1}, &to, CopyOptions{IgnoreNotFoundFields: true})
+	assert.NoError(t, err)
+}
+
+func TestCopy_from_map_with_lossy_numbers(t *testing.T) {
+	var to struct {
+		Yummy int
+		Small uint8
+	}
+
+	err := CopyWithOptions(map[string]interface{}{"Yummy": 1.9}, &to, CopyOptions{})
+	assert.EqualError(t, err, "Provided value type (float64) didn't match obj field type (int)\n")
+
+	err = CopyWithOptions(map[string]interface{}{"Small": -1.5}, &to, CopyOptions{})
+	assert.EqualError(t, err, "Provided value type (float64) didn't match obj field type (uint8)\n")
+
+	report, err := CopyWithReport(map[string]interface{}{"Yummy": 2.0, "Small": 300}, &to, DefaultCopyOptions)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Small"}, report.TypeMismatched)
+	assert.Equal(t, 2, to.Yummy)
+	assert.Zero(t, to.Small)
+}
+
+func TestCopy_from_map_with_nil_values(t *testing.T) {
+	to := TestMapsStruct{Dummy: "keep", Nested: &NestedStruct{}}
+
+	err := CopyWithOptions(map[string]interface{}{"Dummy": nil, "Nested": nil}, &to, CopyOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, TestMapsStruct{Dummy: "keep", Nested: &NestedStruct{}}, to)
+
+	err = Copy(map[string]interface{}{"Dummy": nil, "Nested": nil}, &to)
+	assert.NoError(t, err)
+	assert.Equal(t, TestMapsStruct{}, to)
+}
+
+func TestCopy_from_map_to_non_struct(t *testing.T) {
+	to := "test"
+
+	err := Copy(map[string]interface{}{"Dummy": "test"}, &to)
+	assert.Error(t, err)
+}
+
+func TestCopy_to_nested_map(t *testing.T) {
+	now := time.Now()
+	from := TestMapsStruct{
+		Dummy:     "test",
+		CreatedAt: now,
+		Nested:    &NestedStruct{Dummy: "nested"},
+	}
+	var to map[string]interface{}
+
+	err := CopyWithOptions(&from, &to, CopyOptions{CopyZeroValues: true})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"Dummy":     "test",
+		"Yummy":     0,
+		"Score":     0.0,
+		"CreatedAt": now,
+		"Labels":    map[string]interface{}(nil),
+		"Nested":    map[string]interface{}{"Dummy": "nested", "Yummy": 0},
+		"Inner": map[string]interface{}{
+			"Dummy":  "",
+			"Yummy":  0,
+			"Nested": map[string]interface{}{"Dummy": "", "Yummy": 0},
+		},
+	}, to)
+}
+
+func TestCopy_to_flat_map_without_zero_values(t *testing.T) {
+	from := TestMapsStruct{
+		Dummy:  "test",
+		Nested: &NestedStruct{Yummy: 1},
+	}
+	to := map[string]interface{}{"Other": true}
+
+	err := CopyWithOptions(from, &to, CopyOptions{FlattenMaps: true})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"Other":        true,
+		"Dummy":        "test",
+		"Nested.Yummy": 1,
+	}, to)
+}
+
+func TestCopy_to_map_from_non_struct(t *testing.T) {
+	var to map[string]interface{}
+
+	err := Copy("test", &to)
+	assert.Error(t, err)
+}
+
+func TestCopy_from_map_to_map(t *testing.T) {
+	from := map[string]interface{}{
+		"Dummy":        "test",
+		"Yummy":        0,
+		"Nested.Dummy": "nested",
+	}
+	to := map[string]interface{}{"Yummy": 1}
+
+	err := CopyWithOptions(from, &to, CopyOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"Dummy":  "test",
+		"Yummy":  1,
+		"Nested": map[string]interface{}{"Dummy": "nested"},
+	}, to)
+}
+
+func TestCopy_from_map_to_map_with_nested_maps(t *testing.T) {
+	from := map[string]interface{}{
+		"Payload": map[string]interface{}{
+			"Name":  "name",
+			"Items": []interface{}{map[string]interface{}{"ID": 1}},
+			"Empty": map[string]interface{}{},
+		},
+	}
+	to := map[string]interface{}{}
+
+	err := CopyWithOptions(from, &to, CopyOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, from, to)
+
+	payload := to["Payload"].(map[string]interface{})
+	payload["Name"] = "changed"
+	payload["Items"].([]interface{})[0].(map[string]interface{})["ID"] = 2
+	payload["Empty"].(map[string]interface{})["Key"] = "value"
+	assert.Equal(t, map[string]interface{}{
+		"Payload": map[string]interface{}{
+			"Name":  "name",
+			"Items": []interface{}{map[string]interface{}{"ID": 1}},
+			"Empty": map[string]interface{}{},
+		},
+	}, from)
+
+	flat := map[string]interface{}{}
+	err = CopyWithOptions(from, &flat, CopyOptions{FlattenMaps: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "name", flat["Payload.Name"])
+
+	nils := map[string]interface{}{}
+	err = CopyWithOptions(map[string]interface{}{"Map": map[string]interface{}(nil), "Slice": []interface{}(nil)}, &nils, CopyOptions{CopyZeroValues: true})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"Map": map[string]interface{}(nil), "Slice": []interface{}(nil)}, nils)
+}
+
+func TestCopy_from_map_to_map_with_include_and_exclude(t *testing.T) {
+	from := map[string]interface{}{
+		"Dummy":        "test",
+		"Nested.Dummy": "nested",
+		"Inner": map[string]interface{}{
+			"Dummy": "inner",
+			"Yummy": 1,
+		},
+	}
+
+	to := map[string]interface{}{}
+	report, err := CopyWithReport(from, &to, CopyOptions{Include: []string{"Inner", "Nested.*"}, Exclude: []string{"*.Yummy"}})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"Nested": map[string]interface{}{"Dummy": "nested"},
+		"Inner":  map[string]interface{}{"Dummy": "inner"},
+	}, to)
+	assert.Equal(t, []string{"Inner.Dummy", "Nested.Dummy"}, report.Copied)
+}
@@ -0,0 +1,177 @@
+package reflectme
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ApplyFieldMask copies only the provided paths from src to dst, like
+// a protobuf FieldMask applied to an update. Zero values are copied, paths
+// below nil src pointers are cleared and nil pointers in the middle of the
+// dst paths are allocated. Paths can use
+// "*" to match any segment. Slices and maps are copied as a whole, so
+// paths to their elements, like "Items[0].Name" or "Items.*.Name", are
+// invalid. An empty mask copies nothing.
+func ApplyFieldMask(src interface{}, dst interface{}, paths []string) error {
+	if !hasValidType(src, []reflect.Kind{reflect.Struct, reflect.Ptr}) {
+		return errors.New("Cannot use ApplyFieldMask on a non-struct interface")
+	}
+	if err := checkSelectedPaths(reflect.TypeOf(src), paths); err != nil {
+		return err
+	}
+	for _, path := range paths {
+		if strings.Contains(path, "*") {
+			continue
+		}
+		if _, err := resolveTypePath(reflect.TypeOf(src), path, PathOptions{}); err != nil {
+			return fmt.Errorf("Invalid field mask path: %s", path)
+		}
+	}
+	if len(paths) == 0 {
+		return nil
+	}
+
+	// A nil src is applied as an empty one, clearing the paths
+	if v := reflect.ValueOf(src); v.Kind() == reflect.Ptr && v.IsNil() {
+		src = reflect.New(v.Type().Elem()).Interface()
+	}
+	options := CopyOptions{CopyZeroValues: true, Include: paths}
+	if err := CopyWithOptions(src, dst, options); err != nil {
+		return err
+	}
+
+	return clearUnsetPaths(src, dst, options)
+}
+
+// clearUnsetPaths sets the zero value in dst of the selected paths below
+// nil src pointers, which the copy doesn't walk. Paths below a selected
+// path were already copied as a whole.
+func clearUnsetPaths(src interface{}, dst interface{}, options CopyOptions) error {
+	// src is a struct or pointer to struct so should never get an error
+	fields, _ := TypeFieldsNames(reflect.TypeOf(src), TraverseOptions{ExpandNilPointers: true})
+	var selected []string
+	for i, field := range fields {
+		hasNested := i+1 < len(fields) && isNestedPath(field, fields[i+1])
+		if !options.selects(field, hasNested) {
+			continue
+		}
+		if hasSelectedParent(selected, field) {
+			continue
+		}
+		selected = append(selected, field)
+		if _, err := getInnerField(src, field); err == nil {
+			continue
+		}
+
+		t, err := resolveTypePath(reflect.TypeOf(dst), field, PathOptions{})
+		if err != nil {
+			return fmt.Errorf("No such field: %s in obj", field)
+		}
+		if err := SetField(dst, field, reflect.Zero(t).Interface()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func hasSelectedParent(selected []string, path string) bool {
+	for _, parent := range selected {
+		if isNestedPath(parent, path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// selects indicates if the path is copied according to the Include and
+// Exclude options. Paths with nested fields and a masked nested path are
+// not copied as a whole, as their nested paths are copied one by one.
+func (o CopyOptions) selects(path string, hasNested bool) bool {
+	if len(o.Include) > 0 && (!matchesAnyPath(o.Include, path) || (hasNested && hasNestedPattern(o.Include, path))) {
+		return false
+	}
+	if matchesAnyPath(o.Exclude, path) || (hasNested && hasNestedPattern(o.Exclude, path)) {
+		return false
+	}
+
+	return true
+}
+
+// checkSelectedPaths returns an error for the patterns selecting slice,
+// array or map elements of t, which are only copied as a whole
+func checkSelectedPaths(t reflect.Type, patterns ...[]string) error {
+	for _, list := range patterns {
+		for _, pattern := range list {
+			if strings.Contains(pattern, "[") || selectsElements(t, strings.Split(pattern, ".")) {
+				return fmt.Errorf("Cannot select collection elements in path %s", pattern)
+			}
+		}
+	}
+
+	return nil
+}
+
+// selectsElements indicates if the segments name a collection field of
+// t followed by more segments. "*" segments don't match collections.
+func selectsElements(t reflect.Type, segments []string) bool {
+	t = indirectType(t)
+	if len(segments) < 2 || t.Kind() != reflect.Struct {
+		return false
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !isExportableField(field) || (segments[0] != "*" && segments[0] != field.Name) {
+			continue
+		}
+		isCollection := isCollectionKind(indirectType(field.Type).Kind())
+		if (isCollection && segments[0] != "*") || (!isCollection && selectsElements(field.Type, segments[1:])) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchesAnyPath indicates if any pattern matches the path or one
+// of its parents
+func matchesAnyPath(patterns []string, path string) bool {
+	segments := strings.Split(path, ".")
+	for _, pattern := range patterns {
+		patternSegments := strings.Split(pattern, ".")
+		if len(patternSegments) <= len(segments) && matchSegments(patternSegments, segments) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasNestedPattern indicates if any pattern matches a path nested in path
+func hasNestedPattern(patterns []string, path string) bool {
+	segments := strings.Split(path, ".")
+	for _, pattern := range patterns {
+		patternSegments := strings.Split(pattern, ".")
+		if len(patternSegments) > len(segments) && matchSegments(segments, patternSegments) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchSegments indicates if the shortest segments list matches the
+// beginning of the other, with "*" matching any segment
+func matchSegments(a, b []string) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] && a[i] != "*" && b[i] != "*" {
+			return false
+		}
+	}
+
+	return true
+}
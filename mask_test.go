@@ -0,0 +1,166 @@
+package reflectme
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TestMaskStruct struct {
+	Name    string
+	Age     int
+	Address *TestMaskAddress
+	Home    TestMaskAddress
+	Items   []TestMaskAddress
+}
+
+type TestMaskAddress struct {
+	Street string
+	Zip    string
+}
+
+func TestCopy_with_include(t *testing.T) {
+	from := TestMaskStruct{
+		Name:    "new",
+		Age:     10,
+		Address: &TestMaskAddress{Street: "street", Zip: "zip"},
+		Home:    TestMaskAddress{Street: "home", Zip: "home zip"},
+	}
+	to := TestMaskStruct{Name: "old", Age: 1, Home: TestMaskAddress{Zip: "old zip"}}
+
+	err := CopyWithOptions(from, &to, CopyOptions{CopyZeroValues: true, Include: []string{"Name", "Address.Zip", "Home.Street"}})
+	assert.NoError(t, err)
+	assert.Equal(t, TestMaskStruct{
+		Name:    "new",
+		Age:     1,
+		Address: &TestMaskAddress{Zip: "zip"},
+		Home:    TestMaskAddress{Street: "home", Zip: "old zip"},
+	}, to)
+	assert.NotSame(t, from.Address, to.Address)
+}
+
+func TestCopy_with_exclude_and_wildcard(t *testing.T) {
+	from := TestMaskStruct{
+		Name: "new",
+		Age:  10,
+		Home: TestMaskAddress{Street: "home", Zip: "home zip"},
+	}
+	to := TestMaskStruct{}
+
+	err := CopyWithOptions(from, &to, CopyOptions{CopyZeroValues: true, Exclude: []string{"Age", "*.Zip"}})
+	assert.NoError(t, err)
+	assert.Equal(t, TestMaskStruct{Name: "new", Home: TestMaskAddress{Street: "home"}}, to)
+
+	to = TestMaskStruct{}
+	err = CopyWithOptions(from, &to, CopyOptions{CopyZeroValues: true, Include: []string{"Home"}, Exclude: []string{"Home.Street"}})
+	assert.NoError(t, err)
+	assert.Equal(t, TestMaskStruct{Home: TestMaskAddress{Zip: "home zip"}}, to)
+}
+
+func TestCopy_with_include_from_and_to_maps(t *testing.T) {
+	from := map[string]interface{}{
+		"Name": "new",
+		"Age":  10,
+		"Home": map[string]interface{}{"Street": "home", "Zip": "zip"},
+	}
+	to := TestMaskStruct{}
+
+	err := CopyWithOptions(from, &to, CopyOptions{Include: []string{"Name", "Home.Zip"}})
+	assert.NoError(t, err)
+	assert.Equal(t, TestMaskStruct{Name: "new", Home: TestMaskAddress{Zip: "zip"}}, to)
+
+	var toMap map[string]interface{}
+	err = CopyWithOptions(to, &toMap, CopyOptions{Exclude: []string{"Home"}})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"Name": "new"}, toMap)
+}
+
+func TestApplyFieldMask(t *testing.T) {
+	src := TestMaskStruct{
+		Name:    "",
+		Age:     10,
+		Address: &TestMaskAddress{Street: "street"},
+	}
+	dst := TestMaskStruct{Name: "old", Age: 1}
+
+	err := ApplyFieldMask(&src, &dst, []string{"Name", "Address.Street", "Home.*"})
+	assert.NoError(t, err)
+	assert.Equal(t, TestMaskStruct{Age: 1, Address: &TestMaskAddress{Street: "street"}}, dst)
+
+	err = ApplyFieldMask(src, &dst, nil)
+	assert.NoError(t, err)
+}
+
+func TestApplyFieldMask_on_nil_source_pointers(t *testing.T) {
+	dst := TestMaskStruct{Name: "old", Address: &TestMaskAddress{Street: "old", Zip: "zip"}}
+
+	err := ApplyFieldMask(TestMaskStruct{}, &dst, []string{"Address.Street"})
+	assert.NoError(t, err)
+	assert.Equal(t, TestMaskStruct{Name: "old", Address: &TestMaskAddress{Zip: "zip"}}, dst)
+
+	dst = TestMaskStruct{}
+	err = ApplyFieldMask(TestMaskStruct{}, &dst, []string{"Address.*"})
+	assert.NoError(t, err)
+	assert.Equal(t, TestMaskStruct{Address: &TestMaskAddress{}}, dst)
+
+	err = ApplyFieldMask(TestMaskStruct{}, &dst, []string{"Address"})
+	assert.NoError(t, err)
+	assert.Equal(t, TestMaskStruct{}, dst)
+
+	dst = TestMaskStruct{Name: "old", Home: TestMaskAddress{Street: "old"}}
+	err = ApplyFieldMask((*TestMaskStruct)(nil), &dst, []string{"Name", "Home"})
+	assert.NoError(t, err)
+	assert.Equal(t, TestMaskStruct{}, dst)
+
+	err = ApplyFieldMask(TestMaskStruct{}, &struct{ Address *NestedStruct }{}, []string{"Address.Street"})
+	assert.EqualError(t, err, "No such field: Address.Street in obj")
+
+	err = ApplyFieldMask(TestMaskStruct{}, &struct{ Address interface{} }{}, []string{"Address.Street"})
+	assert.EqualError(t, err, "No such field: Address.Street in obj")
+}
+
+func TestApplyFieldMask_with_errors(t *testing.T) {
+	dst := TestMaskStruct{}
+
+	err := ApplyFieldMask(TestMaskStruct{}, &dst, []string{"Obladioblada"})
+	assert.Error(t, err)
+
+	err = ApplyFieldMask("test", &dst, []string{"Name"})
+	assert.Error(t, err)
+
+	err = ApplyFieldMask(TestMaskStruct{Name: "a"}, &TestDummyOnlyStruct{}, []string{"Name"})
+	assert.Error(t, err)
+
+	src := TestMaskStruct{Items: []TestMaskAddress{{Street: "street"}}}
+	err = ApplyFieldMask(src, &dst, []string{"Items[0].Street"})
+	assert.EqualError(t, err, "Cannot select collection elements in path Items[0].Street")
+	err = ApplyFieldMask(src, &dst, []string{"Items.*.Street"})
+	assert.EqualError(t, err, "Cannot select collection elements in path Items.*.Street")
+	assert.Empty(t, dst.Items)
+}
+
+func TestCopy_with_collection_element_paths(t *testing.T) {
+	from := TestMaskStruct{Name: "new", Items: []TestMaskAddress{{Street: "street", Zip: "zip"}}}
+	cases := []CopyOptions{
+		{Include: []string{"Items.*.Name"}},
+		{Include: []string{"Items.Street"}},
+		{Include: []string{"Items[0].Street"}},
+		{Exclude: []string{"Items.*.Zip"}},
+	}
+	for _, options := range cases {
+		to := TestMaskStruct{}
+		err := CopyWithOptions(from, &to, options)
+		assert.Regexp(t, `^Cannot select collection elements in path Items`, err)
+		assert.Equal(t, TestMaskStruct{}, to)
+
+		_, err = Plan(reflect.TypeOf(from), reflect.TypeOf(to), options)
+		assert.Regexp(t, `^Cannot select collection elements in path Items`, err)
+	}
+
+	// Collections are selected as a whole and "*" segments skip them
+	to := TestMaskStruct{}
+	err := CopyWithOptions(from, &to, CopyOptions{Include: []string{"Items", "*.Zip"}})
+	assert.NoError(t, err)
+	assert.Equal(t, TestMaskStruct{Items: from.Items}, to)
+}
@@ -0,0 +1,301 @@
+package reflectme
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+type (
+	// MergeStrategy defines how a "from" value is merged into a "to" value
+	MergeStrategy int
+
+	// MergeOptions are options for merge function
+	MergeOptions struct {
+		// Default is the strategy for the kinds not in Kinds
+		Default MergeStrategy
+		// Kinds are the strategies by kind
+		Kinds map[reflect.Kind]MergeStrategy
+		// MergeZeroValues lets zero values overwrite non zero ones
+		MergeZeroValues bool
+	}
+
+	merger struct {
+		MergeOptions
+		// merged are the "to" pointers of the "from" pointers already
+		// merged, so cycles and shared pointers are kept
+		merged map[visitedPointer]reflect.Value
+	}
+)
+
+const (
+	// MergeOverwrite replaces the "to" value
+	MergeOverwrite MergeStrategy = iota
+	// MergeKeepNonZero keeps the "to" value when it's non zero
+	MergeKeepNonZero
+	// MergeAppend appends the "from" slice to the "to" slice
+	MergeAppend
+	// MergeUnion appends the "from" slice elements that aren't in the "to"
+	// slice, merging the ones that are. Elements are matched by the field
+	// in the key tag option, eg `merge:"union,key=ID"`, or by value.
+	MergeUnion
+	// MergeDeep merges maps by key and structs field by field,
+	// allocating nil pointers as needed. Structs with no exported
+	// fields are overwritten.
+	MergeDeep
+)
+
+var (
+	// DefaultMergeOptions are the default options for merge function
+	DefaultMergeOptions = MergeOptions{
+		Default: MergeOverwrite,
+		Kinds: map[reflect.Kind]MergeStrategy{
+			reflect.Struct:    MergeDeep,
+			reflect.Ptr:       MergeDeep,
+			reflect.Map:       MergeDeep,
+			reflect.Interface: MergeDeep,
+		},
+	}
+
+	mergeStrategies = map[string]MergeStrategy{
+		"overwrite": MergeOverwrite,
+		"keep":      MergeKeepNonZero,
+		"append":    MergeAppend,
+		"union":     MergeUnion,
+		"deep":      MergeDeep,
+	}
+)
+
+// Merge merges all values from "from" into "to" with
+// DefaultMergeOptions
+func Merge(from interface{}, to interface{}) error {
+	return MergeWithOptions(from, to, DefaultMergeOptions)
+}
+
+// MergeWithOptions merges all values from "from" into "to" with
+// MergeOptions. "to" must be a pointer to a value of the same type as
+// "from", which can whether be a value or a pointer to it. Struct fields
+// can override the strategy with the merge tag, eg `merge:"append"`,
+// `merge:"union,key=ID"` or `merge:"-"` to skip the field. Pointers
+// merged deep are merged once, so cycles in "from" are kept in "to".
+func MergeWithOptions(from interface{}, to interface{}, options MergeOptions) error {
+	if to == nil || !isPointer(to) {
+		return errors.New("To must be a pointer")
+	}
+
+	m := &merger{MergeOptions: options, merged: make(map[visitedPointer]reflect.Value)}
+	toValue := reflect.ValueOf(to).Elem()
+	fromValue := reflect.ValueOf(from)
+	if fromValue.Kind() == reflect.Ptr && fromValue.Type().Elem() == toValue.Type() {
+		if fromValue.IsNil() {
+			return nil
+		}
+		m.merged[visitedPointer{fromValue.Pointer(), fromValue.Type()}] = reflect.ValueOf(to)
+		fromValue = fromValue.Elem()
+	}
+	if !fromValue.IsValid() || fromValue.Type() != toValue.Type() {
+		return fmt.Errorf("Provided value type (%v) didn't match obj type (%v)", reflect.TypeOf(from), toValue.Type())
+	}
+
+	return m.merge(toValue, fromValue, options.strategy(toValue.Type()), "")
+}
+
+func (o MergeOptions) strategy(t reflect.Type) MergeStrategy {
+	if s, ok := o.Kinds[t.Kind()]; ok {
+		return s
+	}
+
+	return o.Default
+}
+
+func (o *merger) merge(to, from reflect.Value, strategy MergeStrategy, key string) error {
+	switch strategy {
+	case MergeKeepNonZero:
+		if !to.IsZero() {
+			return nil
+		}
+	case MergeAppend:
+		if to.Kind() == reflect.Slice {
+			if from.Len() > 0 {
+				to.Set(reflect.AppendSlice(to, from))
+			}
+			return nil
+		}
+	case MergeUnion:
+		if to.Kind() == reflect.Slice {
+			return o.mergeUnion(to, from, key)
+		}
+	case MergeDeep:
+		merged, err := o.mergeDeep(to, from)
+		if merged || err != nil {
+			return err
+		}
+	}
+
+	if !o.MergeZeroValues && from.IsZero() {
+		return nil
+	}
+	to.Set(from)
+
+	return nil
+}
+
+// mergeDeep merges structs, pointers, maps and interfaces holding them.
+// It returns false for other kinds, which are overwritten.
+func (o *merger) mergeDeep(to, from reflect.Value) (bool, error) {
+	switch to.Kind() {
+	case reflect.Struct:
+		// Structs with no exported fields, like time.Time or Optional,
+		// are merged as a whole
+		if !hasExportableFields(to.Type()) {
+			return false, nil
+		}
+		return true, o.mergeStruct(to, from)
+	case reflect.Ptr:
+		if from.IsNil() {
+			return true, nil
+		}
+		key := visitedPointer{from.Pointer(), from.Type()}
+		if merged, ok := o.merged[key]; ok {
+			to.Set(merged)
+			return true, nil
+		}
+		if to.IsNil() {
+			to.Set(reflect.New(to.Type().Elem()))
+		}
+		merged := reflect.New(to.Type()).Elem()
+		merged.Set(to)
+		o.merged[key] = merged
+		return true, o.merge(to.Elem(), from.Elem(), o.strategy(to.Type().Elem()), "")
+	case reflect.Map:
+		if from.IsNil() {
+			return true, nil
+		}
+		if to.IsNil() {
+			to.Set(reflect.MakeMapWithSize(to.Type(), from.Len()))
+		}
+		elemType := to.Type().Elem()
+		iter := from.MapRange()
+		for iter.Next() {
+			// Map values aren't addressable, so they are merged in a copy
+			elem := reflect.New(elemType).Elem()
+			if current := to.MapIndex(iter.Key()); current.IsValid() {
+				elem.Set(current)
+			}
+			if err := o.merge(elem, iter.Value(), o.strategy(elemType), ""); err != nil {
+				return true, err
+			}
+			to.SetMapIndex(iter.Key(), elem)
+		}
+		return true, nil
+	case reflect.Interface:
+		if to.IsNil() || from.IsNil() || to.Elem().Type() != from.Elem().Type() {
+			return false, nil
+		}
+		elem := reflect.New(to.Elem().Type()).Elem()
+		elem.Set(to.Elem())
+		if err := o.merge(elem, from.Elem(), o.strategy(elem.Type()), ""); err != nil {
+			return true, err
+		}
+		to.Set(elem)
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// hasExportableFields indicates if the t struct has exported fields
+func hasExportableFields(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if isExportableField(t.Field(i)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (o *merger) mergeStruct(to, from reflect.Value) error {
+	t := to.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !isExportableField(field) {
+			continue
+		}
+
+		strategy := o.strategy(field.Type)
+		key := ""
+		if tag, ok := field.Tag.Lookup("merge"); ok {
+			name, options := parseTagValue(tag)
+			if name == "-" {
+				continue
+			}
+			s, ok := mergeStrategies[name]
+			if !ok {
+				return fmt.Errorf("Invalid merge strategy: %s in field %s", name, field.Name)
+			}
+			strategy = s
+			key, _ = Tag{Options: options}.OptionValue("key")
+		}
+
+		if err := o.merge(to.Field(i), from.Field(i), strategy, key); err != nil {
+			return fmt.Errorf("Cannot merge field %s: %v", field.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// mergeUnion merges the from slice into a copy of the to slice,
+// matching elements by key
+func (o *merger) mergeUnion(to, from reflect.Value, key string) error {
+	if from.Len() == 0 {
+		return nil
+	}
+	result := reflect.MakeSlice(to.Type(), to.Len(), to.Len()+from.Len())
+	reflect.Copy(result, to)
+
+	elemType := to.Type().Elem()
+	indexes := make(map[interface{}]int)
+	for i := 0; i < result.Len(); i++ {
+		k, err := unionKey(result.Index(i), key)
+		if err != nil {
+			return err
+		}
+		indexes[k] = i
+	}
+
+	for i := 0; i < from.Len(); i++ {
+		elem := from.Index(i)
+		k, err := unionKey(elem, key)
+		if err != nil {
+			return err
+		}
+		if j, ok := indexes[k]; ok {
+			if err := o.merge(result.Index(j), elem, o.strategy(elemType), ""); err != nil {
+				return err
+			}
+			continue
+		}
+		indexes[k] = result.Len()
+		result = reflect.Append(result, elem)
+	}
+	to.Set(result)
+
+	return nil
+}
+
+func unionKey(elem reflect.Value, key string) (interface{}, error) {
+	k := elem.Interface()
+	if len(key) > 0 {
+		var err error
+		if k, err = GetField(k, key); err != nil {
+			return nil, err
+		}
+	}
+	if k != nil && !reflect.TypeOf(k).Comparable() {
+		return nil, fmt.Errorf("Union key type (%v) is not comparable", reflect.TypeOf(k))
+	}
+
+	return k, nil
+}
@@ -0,0 +1,284 @@
+package reflectme
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TestMergeItem struct {
+	ID    int
+	Name  string
+	Score int
+}
+
+type TestMergeStruct struct {
+	Name     string
+	Port     int
+	Debug    bool
+	Owner    string          `merge:"keep"`
+	Tags     []string        `merge:"append"`
+	Hosts    []string        `merge:"union"`
+	Items    []TestMergeItem `merge:"union,key=ID"`
+	Replaced []string
+	Secret   string `merge:"-"`
+	Labels   map[string]string
+	Settings map[string]interface{}
+	Nested   NestedStruct
+	Pointer  *NestedStruct
+	Any      interface{}
+	private  string
+}
+
+type TestMergeInvalidStruct struct {
+	Name string `merge:"obladioblada"`
+}
+
+type TestMergeInvalidKeyStruct struct {
+	Items []TestMergeItem `merge:"union,key=Obladioblada"`
+}
+
+type TestMergeUncomparableStruct struct {
+	Items [][]int `merge:"union"`
+}
+
+func TestMerge(t *testing.T) {
+	to := TestMergeStruct{
+		Name:     "base",
+		Port:     80,
+		Debug:    true,
+		Owner:    "alice",
+		Tags:     []string{"a"},
+		Hosts:    []string{"h1", "h2"},
+		Items:    []TestMergeItem{{ID: 1, Name: "one", Score: 1}, {ID: 2, Name: "two"}},
+		Replaced: []string{"old"},
+		Secret:   "keep",
+		Labels:   map[string]string{"env": "dev", "team": "core"},
+		Settings: map[string]interface{}{"db": map[string]interface{}{"host": "localhost", "port": 5432}},
+		Nested:   NestedStruct{Dummy: "dummy", Yummy: 1},
+		Any:      map[string]interface{}{"a": 1},
+		private:  "private",
+	}
+	from := TestMergeStruct{
+		Name:     "override",
+		Owner:    "bob",
+		Tags:     []string{"b"},
+		Hosts:    []string{"h2", "h3"},
+		Items:    []TestMergeItem{{ID: 1, Name: "uno"}, {ID: 3, Name: "three"}},
+		Replaced: []string{"new"},
+		Secret:   "leak",
+		Labels:   map[string]string{"env": "prod"},
+		Settings: map[string]interface{}{"db": map[string]interface{}{"host": "db"}},
+		Nested:   NestedStruct{Yummy: 2},
+		Pointer:  &NestedStruct{Dummy: "pointer"},
+		Any:      map[string]interface{}{"b": 2},
+		private:  "leak",
+	}
+
+	err := Merge(from, &to)
+	assert.NoError(t, err)
+
+	assert.Equal(t, TestMergeStruct{
+		Name:     "override",
+		Port:     80,
+		Debug:    true,
+		Owner:    "alice",
+		Tags:     []string{"a", "b"},
+		Hosts:    []string{"h1", "h2", "h3"},
+		Items:    []TestMergeItem{{ID: 1, Name: "uno", Score: 1}, {ID: 2, Name: "two"}, {ID: 3, Name: "three"}},
+		Replaced: []string{"new"},
+		Secret:   "keep",
+		Labels:   map[string]string{"env": "prod", "team": "core"},
+		Settings: map[string]interface{}{"db": map[string]interface{}{"host": "db", "port": 5432}},
+		Nested:   NestedStruct{Dummy: "dummy", Yummy: 2},
+		Pointer:  &NestedStruct{Dummy: "pointer"},
+		Any:      map[string]interface{}{"a": 1, "b": 2},
+		private:  "private",
+	}, to)
+	assert.NotSame(t, from.Pointer, to.Pointer)
+}
+
+func TestMerge_on_nil_destination_collections(t *testing.T) {
+	to := TestMergeStruct{}
+	from := &TestMergeStruct{
+		Items:  []TestMergeItem{{ID: 1}, {ID: 1, Name: "one"}},
+		Labels: map[string]string{"env": "prod"},
+	}
+
+	err := Merge(from, &to)
+	assert.NoError(t, err)
+	assert.Equal(t, []TestMergeItem{{ID: 1, Name: "one"}}, to.Items)
+	assert.Equal(t, map[string]string{"env": "prod"}, to.Labels)
+
+	err = Merge((*TestMergeStruct)(nil), &to)
+	assert.NoError(t, err)
+	assert.Equal(t, []TestMergeItem{{ID: 1, Name: "one"}}, to.Items)
+}
+
+func TestMerge_on_cycles(t *testing.T) {
+	type node struct {
+		Name  string
+		Next  *node
+		Peers map[string]*node
+	}
+
+	from := &node{Name: "from"}
+	from.Next = from
+	to := node{}
+	assert.NoError(t, Merge(from, &to))
+	assert.Equal(t, "from", to.Name)
+	assert.Same(t, &to, to.Next)
+
+	// Cycles below the root and shared pointers are kept too
+	shared := &node{Name: "shared"}
+	shared.Next = shared
+	value := node{Next: shared, Peers: map[string]*node{"a": shared}}
+	to = node{}
+	assert.NoError(t, Merge(value, &to))
+	assert.NotSame(t, shared, to.Next)
+	assert.Equal(t, "shared", to.Next.Name)
+	assert.Same(t, to.Next, to.Next.Next)
+	assert.Same(t, to.Next, to.Peers["a"])
+}
+
+func TestMerge_on_structs_without_exported_fields(t *testing.T) {
+	type withLeaves struct {
+		When  time.Time
+		Since time.Time `merge:"keep"`
+		Opt   Optional[int]
+		Keep  Optional[int]
+	}
+	now := time.Now()
+	to := withLeaves{Since: now, Keep: Some(1)}
+
+	err := Merge(withLeaves{When: now, Since: now.Add(time.Hour), Opt: Some(3)}, &to)
+	assert.NoError(t, err)
+	assert.Equal(t, withLeaves{When: now, Since: now, Opt: Some(3), Keep: Some(1)}, to)
+
+	err = Merge(withLeaves{Opt: Null[int]()}, &to)
+	assert.NoError(t, err)
+	assert.Equal(t, withLeaves{When: now, Since: now, Opt: Null[int](), Keep: Some(1)}, to)
+}
+
+func TestMergeWithOptions(t *testing.T) {
+	to := TestMergeStruct{
+		Name:    "base",
+		Port:    80,
+		Labels:  map[string]string{"env": "dev"},
+		Nested:  NestedStruct{Dummy: "dummy", Yummy: 1},
+		Pointer: &NestedStruct{Dummy: "pointer", Yummy: 1},
+		Any:     "any",
+	}
+	from := TestMergeStruct{
+		Name:    "override",
+		Labels:  map[string]string{"team": "core"},
+		Nested:  NestedStruct{Yummy: 2},
+		Pointer: &NestedStruct{Yummy: 2},
+		Any:     1,
+	}
+
+	err := MergeWithOptions(from, &to, MergeOptions{
+		Default: MergeKeepNonZero,
+		Kinds: map[reflect.Kind]MergeStrategy{
+			reflect.Struct: MergeDeep,
+			reflect.Map:    MergeOverwrite,
+		},
+		MergeZeroValues: true,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "base", to.Name)
+	assert.Equal(t, 80, to.Port)
+	assert.Equal(t, map[string]string{"team": "core"}, to.Labels)
+	assert.Equal(t, NestedStruct{Dummy: "dummy", Yummy: 1}, to.Nested)
+	assert.Equal(t, &NestedStruct{Dummy: "pointer", Yummy: 1}, to.Pointer)
+	assert.Equal(t, "any", to.Any)
+
+	err = MergeWithOptions(from, &to, MergeOptions{MergeZeroValues: true})
+	assert.NoError(t, err)
+	assert.Equal(t, from, to)
+}
+
+func TestMerge_on_scalars_and_collections(t *testing.T) {
+	to := map[string][]int{"a": {1}}
+	err := MergeWithOptions(map[string][]int{"a": {2}, "b": {3}}, &to, MergeOptions{
+		Default: MergeAppend,
+		Kinds:   map[reflect.Kind]MergeStrategy{reflect.Map: MergeDeep},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]int{"a": {1, 2}, "b": {3}}, to)
+
+	n := 1
+	err = MergeWithOptions(2, &n, MergeOptions{Default: MergeAppend})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	err = MergeWithOptions(3, &n, MergeOptions{Default: MergeUnion})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+
+	err = MergeWithOptions(4, &n, MergeOptions{Default: MergeDeep})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, n)
+
+	var nilPointer *NestedStruct
+	err = Merge((*NestedStruct)(nil), &nilPointer)
+	assert.NoError(t, err)
+	assert.Nil(t, nilPointer)
+}
+
+func TestMerge_on_errors(t *testing.T) {
+	to := TestMergeStruct{}
+	err := Merge(TestMergeStruct{}, to)
+	assert.Error(t, err)
+
+	err = Merge(TestMergeStruct{}, nil)
+	assert.Error(t, err)
+
+	err = Merge(NestedStruct{}, &to)
+	assert.Error(t, err)
+
+	err = Merge(nil, &to)
+	assert.Error(t, err)
+
+	err = Merge(TestMergeInvalidStruct{}, &TestMergeInvalidStruct{})
+	assert.EqualError(t, err, "Invalid merge strategy: obladioblada in field Name")
+
+	err = Merge(
+		TestMergeInvalidKeyStruct{Items: []TestMergeItem{{}}},
+		&TestMergeInvalidKeyStruct{},
+	)
+	assert.Error(t, err)
+
+	err = Merge(
+		TestMergeInvalidKeyStruct{Items: []TestMergeItem{{}}},
+		&TestMergeInvalidKeyStruct{Items: []TestMergeItem{{}}},
+	)
+	assert.Error(t, err)
+
+	err = Merge(
+		TestMergeUncomparableStruct{Items: [][]int{{1}}},
+		&TestMergeUncomparableStruct{},
+	)
+	assert.EqualError(t, err, "Cannot merge field Items: Union key type ([]int) is not comparable")
+
+	nested := map[string]TestMergeInvalidStruct{"a": {}}
+	err = Merge(map[string]TestMergeInvalidStruct{"a": {}}, &nested)
+	assert.Error(t, err)
+
+	var any interface{} = TestMergeInvalidStruct{Name: "a"}
+	err = Merge(&any, &any)
+	assert.Error(t, err)
+
+	pointer := &TestMergeInvalidStruct{}
+	err = Merge(&TestMergeInvalidStruct{}, &pointer)
+	assert.Error(t, err)
+
+	items := []TestMergeInvalidStruct{{}}
+	err = MergeWithOptions(items, &items, MergeOptions{
+		Default: MergeUnion,
+		Kinds:   map[reflect.Kind]MergeStrategy{reflect.Struct: MergeDeep},
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,211 @@
+package reflectme
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+)
+
+type (
+	// Method describes an exported method of an obj
+	Method struct {
+		// Name is the method name
+		Name string
+		// Type is the method func type without the receiver
+		Type reflect.Type
+		// Signature is the method func type as a string,
+		// eg "func(int, string) (bool, error)"
+		Signature string
+		// PointerReceiver indicates if the method is only in the
+		// method set of the pointer to obj type
+		PointerReceiver bool
+	}
+)
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// Methods returns the exported methods list of obj sorted by name,
+// including the ones declared with a pointer receiver.
+func Methods(obj interface{}) ([]Method, error) {
+	if obj == nil {
+		return nil, errors.New("Cannot use Methods on a nil interface")
+	}
+
+	objType := reflect.TypeOf(obj)
+	valueType := objType
+	if objType.Kind() == reflect.Ptr {
+		valueType = objType.Elem()
+	} else {
+		objType = reflect.PtrTo(objType)
+	}
+
+	methods := make([]Method, 0, objType.NumMethod())
+	for i := 0; i < objType.NumMethod(); i++ {
+		m := objType.Method(i)
+		_, hasValueReceiver := valueType.MethodByName(m.Name)
+		// Drop the receiver from the signature
+		funcType := reflect.Zero(objType).Method(i).Type()
+		methods = append(methods, Method{
+			Name:            m.Name,
+			Type:            funcType,
+			Signature:       funcType.String(),
+			PointerReceiver: !hasValueReceiver,
+		})
+	}
+	sort.Slice(methods, func(i, j int) bool {
+		return methods[i].Name < methods[j].Name
+	})
+
+	return methods, nil
+}
+
+// Call calls the obj method with the provided name and args. obj can whether
+// be a value or a pointer. When obj is a value, pointer receiver methods
+// are called on a copy of it. Args are converted to the method parameters
+// types when possible, numbers only when they keep their value. If the
+// method last result is an error it is returned as the error and removed
+// from the results.
+func Call(obj interface{}, name string, args ...interface{}) ([]interface{}, error) {
+	if obj == nil {
+		return nil, errors.New("Cannot use Call on a nil interface")
+	}
+	v := reflect.ValueOf(obj)
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		return nil, errors.New("Cannot use Call on a nil pointer")
+	}
+
+	method, err := methodByName(v, name)
+	if err != nil {
+		return nil, err
+	}
+
+	in, err := methodArgs(method.Type(), name, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return methodResults(method.Call(in))
+}
+
+// methodByName finds the method in the pointer to v method set, so
+// value and pointer receiver methods are both found.
+func methodByName(v reflect.Value, name string) (reflect.Value, error) {
+	if v.Kind() != reflect.Ptr {
+		ptr := reflect.New(v.Type())
+		ptr.Elem().Set(v)
+		v = ptr
+	}
+
+	method := v.MethodByName(name)
+	if !method.IsValid() {
+		return method, fmt.Errorf("No such method: %s in obj", name)
+	}
+
+	return method, nil
+}
+
+func methodArgs(methodType reflect.Type, name string, args []interface{}) ([]reflect.Value, error) {
+	numIn := methodType.NumIn()
+	if methodType.IsVariadic() {
+		if len(args) < numIn-1 {
+			return nil, fmt.Errorf("Method %s expects at least %d args, got %d", name, numIn-1, len(args))
+		}
+	} else if len(args) != numIn {
+		return nil, fmt.Errorf("Method %s expects %d args, got %d", name, numIn, len(args))
+	}
+
+	in := make([]reflect.Value, len(args))
+	for i, arg := range args {
+		var paramType reflect.Type
+		if methodType.IsVariadic() && i >= numIn-1 {
+			paramType = methodType.In(numIn - 1).Elem()
+		} else {
+			paramType = methodType.In(i)
+		}
+
+		argValue, err := convertValue(arg, paramType)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid arg %d for method %s: %v", i, name, err)
+		}
+		in[i] = argValue
+	}
+
+	return in, nil
+}
+
+func methodResults(out []reflect.Value) ([]interface{}, error) {
+	var err error
+	if n := len(out); n > 0 && out[n-1].Type() == errorType {
+		if !out[n-1].IsNil() {
+			err = out[n-1].Interface().(error)
+		}
+		out = out[:n-1]
+	}
+
+	results := make([]interface{}, len(out))
+	for i, v := range out {
+		results[i] = v.Interface()
+	}
+
+	return results, err
+}
+
+// convertValue returns value as a t reflect.Value. value must be assignable
+// to t or convertible between types of the same kind or numbers. Numbers
+// are only converted when they keep their value.
+func convertValue(value interface{}, t reflect.Type) (reflect.Value, error) {
+	if value == nil {
+		switch t.Kind() {
+		case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map, reflect.Func, reflect.Chan:
+			return reflect.Zero(t), nil
+		}
+		return reflect.Value{}, fmt.Errorf("Cannot use nil as type %v", t)
+	}
+
+	v := reflect.ValueOf(value)
+	if v.Type().AssignableTo(t) {
+		return v, nil
+	}
+	if isConvertibleType(v.Type(), t) {
+		if converted := v.Convert(t); convertsLosslessly(v, converted) {
+			return converted, nil
+		}
+	}
+
+	return reflect.Value{}, fmt.Errorf("Provided value type (%v) didn't match type (%v)", v.Type(), t)
+}
+
+// convertsLosslessly indicates if the v number kept its value when
+// converted, ie it didn't overflow, change sign or lose its fraction.
+// Floats can still lose precision.
+func convertsLosslessly(v reflect.Value, converted reflect.Value) bool {
+	switch converted.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return !math.IsInf(converted.Float(), 0) || math.IsInf(v.Float(), 0)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return converted.Convert(v.Type()).Equal(v) && isNegative(v) == isNegative(converted)
+	}
+
+	return true
+}
+
+func isNegative(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() < 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() < 0
+	}
+
+	return false
+}
+
+// isConvertibleType indicates if convertValue converts from values into
+// t values, which must be of the same kind or numbers
+func isConvertibleType(from reflect.Type, t reflect.Type) bool {
+	sameKind := from.Kind() == t.Kind() || (isNumberKind(from.Kind()) && isNumberKind(t.Kind()))
+	return sameKind && from.ConvertibleTo(t)
+}
@@ -0,0 +1,196 @@
+package reflectme
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TestMethodsStatus string
+
+type TestMethodsStruct struct {
+	Dummy string
+	Yummy int
+}
+
+func (s TestMethodsStruct) Greet(name string) string {
+	return s.Dummy + " " + name
+}
+
+func (s TestMethodsStruct) Add(a int, b float64) (float64, error) {
+	if a < 0 {
+		return 0, errors.New("negative")
+	}
+	return float64(a) + b + float64(s.Yummy), nil
+}
+
+func (s TestMethodsStruct) Join(sep string, parts ...string) string {
+	return strings.Join(parts, sep)
+}
+
+func (s TestMethodsStruct) Status(status TestMethodsStatus, tags []string) TestMethodsStatus {
+	return status
+}
+
+func (s *TestMethodsStruct) SetYummy(yummy int) {
+	s.Yummy = yummy
+}
+
+func (s *TestMethodsStruct) unexported() {}
+
+func TestMethods_on_struct(t *testing.T) {
+	methods, err := Methods(TestMethodsStruct{})
+	assert.NoError(t, err)
+
+	names := make([]string, len(methods))
+	for i, m := range methods {
+		names[i] = m.Name
+	}
+	assert.Equal(t, []string{"Add", "Greet", "Join", "SetYummy", "Status"}, names)
+	assert.Equal(t, "func(int, float64) (float64, error)", methods[0].Signature)
+	assert.False(t, methods[0].PointerReceiver)
+	assert.Equal(t, "func(int)", methods[3].Signature)
+	assert.True(t, methods[3].PointerReceiver)
+}
+
+func TestMethods_on_struct_pointer(t *testing.T) {
+	methods, err := Methods(&TestMethodsStruct{})
+	assert.NoError(t, err)
+	assert.Equal(t, 5, len(methods))
+	assert.Equal(t, 2, methods[2].Type.NumIn())
+	assert.True(t, methods[2].Type.IsVariadic())
+}
+
+func TestMethods_on_nil(t *testing.T) {
+	_, err := Methods(nil)
+	assert.Error(t, err)
+}
+
+func TestCall_on_value_receiver(t *testing.T) {
+	dummyStruct := TestMethodsStruct{Dummy: "hello"}
+
+	results, err := Call(dummyStruct, "Greet", "world")
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"hello world"}, results)
+
+	results, err = Call(&dummyStruct, "Greet", "world")
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"hello world"}, results)
+}
+
+func TestCall_on_pointer_receiver(t *testing.T) {
+	dummyStruct := TestMethodsStruct{}
+
+	results, err := Call(&dummyStruct, "SetYummy", 10)
+	assert.NoError(t, err)
+	assert.Empty(t, results)
+	assert.Equal(t, 10, dummyStruct.Yummy)
+
+	_, err = Call(dummyStruct, "SetYummy", 20)
+	assert.NoError(t, err)
+	assert.Equal(t, 10, dummyStruct.Yummy)
+}
+
+func TestCall_with_trailing_error(t *testing.T) {
+	dummyStruct := TestMethodsStruct{Yummy: 1}
+
+	results, err := Call(dummyStruct, "Add", int8(2), 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{6.0}, results)
+
+	results, err = Call(dummyStruct, "Add", -1, 3.0)
+	assert.EqualError(t, err, "negative")
+	assert.Equal(t, []interface{}{0.0}, results)
+}
+
+func TestCall_with_variadic_args(t *testing.T) {
+	results, err := Call(TestMethodsStruct{}, "Join", "-", "a", "b")
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"a-b"}, results)
+
+	results, err = Call(TestMethodsStruct{}, "Join", "-")
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{""}, results)
+
+	_, err = Call(TestMethodsStruct{}, "Join")
+	assert.Error(t, err)
+}
+
+func TestCall_with_converted_and_nil_args(t *testing.T) {
+	results, err := Call(TestMethodsStruct{}, "Status", "active", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{TestMethodsStatus("active")}, results)
+}
+
+func TestCall_with_invalid_args(t *testing.T) {
+	_, err := Call(TestMethodsStruct{}, "Greet")
+	assert.Error(t, err)
+
+	_, err = Call(TestMethodsStruct{}, "Greet", 65)
+	assert.Error(t, err)
+
+	_, err = Call(TestMethodsStruct{}, "Greet", nil)
+	assert.Error(t, err)
+}
+
+type TestMethodsNumbers struct{}
+
+func (n TestMethodsNumbers) Uint8(v uint8) uint8 {
+	return v
+}
+
+func (n TestMethodsNumbers) Float32(v float32) float32 {
+	return v
+}
+
+func TestCall_with_lossy_number_args(t *testing.T) {
+	results, err := Call(TestMethodsNumbers{}, "Uint8", 200.0)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{uint8(200)}, results)
+
+	_, err = Call(TestMethodsNumbers{}, "Uint8", 300.7)
+	assert.EqualError(t, err, "Invalid arg 0 for method Uint8: Provided value type (float64) didn't match type (uint8)")
+
+	_, err = Call(TestMethodsNumbers{}, "Uint8", 1.5)
+	assert.Error(t, err)
+
+	_, err = Call(TestMethodsNumbers{}, "Uint8", 300)
+	assert.Error(t, err)
+
+	_, err = Call(TestMethodsNumbers{}, "Uint8", int8(-1))
+	assert.Error(t, err)
+
+	_, err = Call(TestMethodsStruct{}, "Add", uint64(math.MaxUint64), 1.0)
+	assert.Error(t, err)
+
+	_, err = Call(TestMethodsStruct{}, "Add", math.NaN(), 1.0)
+	assert.Error(t, err)
+
+	results, err = Call(TestMethodsNumbers{}, "Float32", 0.1)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{float32(0.1)}, results)
+
+	results, err = Call(TestMethodsNumbers{}, "Float32", math.Inf(-1))
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{float32(math.Inf(-1))}, results)
+
+	_, err = Call(TestMethodsNumbers{}, "Float32", math.MaxFloat64)
+	assert.Error(t, err)
+}
+
+func TestCall_non_existing_method(t *testing.T) {
+	_, err := Call(TestMethodsStruct{}, "obladioblada")
+	assert.Error(t, err)
+
+	_, err = Call(TestMethodsStruct{}, "unexported")
+	assert.Error(t, err)
+
+	_, err = Call(nil, "Greet")
+	assert.Error(t, err)
+
+	_, err = Call((*TestMethodsStruct)(nil), "Greet", "world")
+	assert.EqualError(t, err, "Cannot use Call on a nil pointer")
+}
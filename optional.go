@@ -0,0 +1,180 @@
+package reflectme
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+type optionalState uint8
+
+const (
+	optionalAbsent optionalState = iota
+	optionalNull
+	optionalSet
+)
+
+// Optional is a value that can be absent, null or set, like a field of
+// a JSON PATCH body. Its zero value is absent. GetField returns the set
+// value or nil, SetField wraps values of type T and nil as null, and
+// CopyWithOptions skips absent values, writing null as the zero value
+// when the destination is a plain T. Tag Optional fields with omitzero,
+// eg `json:"name,omitzero"`, so absent values aren't marshaled as null.
+type Optional[T any] struct {
+	value T
+	state optionalState
+}
+
+type (
+	// optional lets reflection read an Optional of any type
+	optional interface {
+		optionalValue() (interface{}, optionalState)
+		optionalElem() reflect.Type
+	}
+
+	// optionalSetter lets reflection write an Optional of any type
+	optionalSetter interface {
+		setOptional(value interface{}, state optionalState)
+	}
+)
+
+var (
+	optionalType       = reflect.TypeOf((*optional)(nil)).Elem()
+	optionalSetterType = reflect.TypeOf((*optionalSetter)(nil)).Elem()
+)
+
+// Some returns an Optional set to value
+func Some[T any](value T) Optional[T] {
+	return Optional[T]{value: value, state: optionalSet}
+}
+
+// Null returns a null Optional
+func Null[T any]() Optional[T] {
+	return Optional[T]{state: optionalNull}
+}
+
+// IsAbsent indicates if the Optional is neither null nor set
+func (o Optional[T]) IsAbsent() bool {
+	return o.state == optionalAbsent
+}
+
+// IsNull indicates if the Optional is null
+func (o Optional[T]) IsNull() bool {
+	return o.state == optionalNull
+}
+
+// IsSet indicates if the Optional has a value
+func (o Optional[T]) IsSet() bool {
+	return o.state == optionalSet
+}
+
+// Get returns the Optional value and if it's set
+func (o Optional[T]) Get() (T, bool) {
+	return o.value, o.state == optionalSet
+}
+
+// PatchValue returns the Optional value and if it's present, so
+// ApplyPatch skips absent values and sets null ones
+func (o Optional[T]) PatchValue() (interface{}, bool) {
+	value, state := o.optionalValue()
+	return value, state != optionalAbsent
+}
+
+// IsZero indicates if the Optional is absent, so the omitzero json
+// option omits absent values
+func (o Optional[T]) IsZero() bool {
+	return o.IsAbsent()
+}
+
+// MarshalJSON encodes the Optional value, or null when absent or null
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if o.state != optionalSet {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(o.value)
+}
+
+// UnmarshalJSON decodes null as a null Optional and any other value as
+// a set one. Missing keys don't call it, so they are left absent.
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*o = Null[T]()
+		return nil
+	}
+
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*o = Some(value)
+
+	return nil
+}
+
+func (o Optional[T]) optionalValue() (interface{}, optionalState) {
+	if o.state != optionalSet {
+		return nil, o.state
+	}
+
+	return o.value, o.state
+}
+
+func (o Optional[T]) optionalElem() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+func (o *Optional[T]) setOptional(value interface{}, state optionalState) {
+	o.value, _ = value.(T)
+	o.state = state
+}
+
+// isOptionalType indicates if t is an Optional
+func isOptionalType(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t.Implements(optionalType) && reflect.PtrTo(t).Implements(optionalSetterType)
+}
+
+// unwrapOptional returns the value of an Optional and if it's present,
+// with nil meaning null. Other values, including *Optional ones, are
+// returned as present.
+func unwrapOptional(value interface{}) (interface{}, bool) {
+	if value == nil || !isOptionalType(reflect.TypeOf(value)) {
+		return value, true
+	}
+	v, state := value.(optional).optionalValue()
+
+	return v, state != optionalAbsent
+}
+
+// setOptionalField sets the addressable Optional target to value, which
+// can be an Optional, a value convertible to the Optional type or nil
+// for null
+func setOptionalField(target reflect.Value, value interface{}) error {
+	setter := target.Addr().Interface().(optionalSetter)
+	if value != nil && reflect.TypeOf(value) == target.Type() {
+		target.Set(reflect.ValueOf(value))
+		return nil
+	}
+
+	if value != nil && isOptionalType(reflect.TypeOf(value)) {
+		var state optionalState
+		value, state = value.(optional).optionalValue()
+		if state != optionalSet {
+			setter.setOptional(nil, state)
+			return nil
+		}
+	}
+	if value == nil {
+		setter.setOptional(nil, optionalNull)
+		return nil
+	}
+
+	converted, err := convertValue(value, target.Interface().(optional).optionalElem())
+	if err != nil {
+		return fmt.Errorf("Provided value type (%v) didn't match obj field type (%v)", reflect.TypeOf(value), target.Type())
+	}
+	setter.setOptional(converted.Interface(), optionalSet)
+
+	return nil
+}
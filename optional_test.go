@@ -0,0 +1,257 @@
+package reflectme
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TestOptionalStruct struct {
+	Name   Optional[string]
+	Age    Optional[int]
+	Nested Optional[NestedStruct]
+	Any    Optional[interface{}]
+}
+
+type TestOptionalTarget struct {
+	Name   string
+	Age    int
+	Nested NestedStruct
+	Any    interface{}
+}
+
+func TestOptional(t *testing.T) {
+	var absent Optional[int]
+	assert.True(t, absent.IsAbsent())
+	assert.False(t, absent.IsNull())
+	assert.False(t, absent.IsSet())
+
+	null := Null[int]()
+	assert.False(t, null.IsAbsent())
+	assert.True(t, null.IsNull())
+	assert.False(t, null.IsSet())
+
+	some := Some(0)
+	assert.False(t, some.IsAbsent())
+	assert.False(t, some.IsNull())
+	assert.True(t, some.IsSet())
+
+	value, ok := some.Get()
+	assert.True(t, ok)
+	assert.Equal(t, 0, value)
+
+	_, ok = null.Get()
+	assert.False(t, ok)
+}
+
+func TestOptional_JSON(t *testing.T) {
+	data, err := json.Marshal(TestOptionalStruct{Name: Some("name"), Age: Null[int]()})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"Name":"name","Age":null,"Nested":null,"Any":null}`, string(data))
+
+	var obj TestOptionalStruct
+	err = json.Unmarshal([]byte(`{"Name":"name","Age": null ,"Nested":{"Yummy":1}}`), &obj)
+	assert.NoError(t, err)
+	assert.Equal(t, TestOptionalStruct{
+		Name:   Some("name"),
+		Age:    Null[int](),
+		Nested: Some(NestedStruct{Yummy: 1}),
+	}, obj)
+
+	err = json.Unmarshal([]byte(`{"Age":"a"}`), &obj)
+	assert.Error(t, err)
+}
+
+func TestOptional_JSON_with_omitzero(t *testing.T) {
+	type patch struct {
+		Name Optional[string] `json:"name,omitzero"`
+		Age  Optional[int]    `json:"age,omitzero"`
+		Note Optional[string] `json:"note,omitzero"`
+	}
+
+	data, err := json.Marshal(patch{Name: Some("name"), Age: Null[int]()})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"name":"name","age":null}`, string(data))
+
+	var obj patch
+	err = json.Unmarshal(data, &obj)
+	assert.NoError(t, err)
+	assert.Equal(t, patch{Name: Some("name"), Age: Null[int]()}, obj)
+	assert.True(t, obj.Note.IsZero())
+	assert.False(t, obj.Age.IsZero())
+}
+
+func TestGetField_on_optional(t *testing.T) {
+	obj := TestOptionalStruct{Name: Some("name"), Age: Null[int]()}
+
+	value, err := GetField(obj, "Name")
+	assert.NoError(t, err)
+	assert.Equal(t, "name", value)
+
+	value, err = GetField(obj, "Age")
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+
+	value, err = GetField(obj, "Nested")
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+}
+
+func TestSetField_on_optional(t *testing.T) {
+	obj := TestOptionalStruct{}
+
+	assert.NoError(t, SetField(&obj, "Name", "name"))
+	assert.Equal(t, Some("name"), obj.Name)
+
+	assert.NoError(t, SetField(&obj, "Age", int32(1)))
+	assert.Equal(t, Some(1), obj.Age)
+
+	assert.NoError(t, SetField(&obj, "Age", nil))
+	assert.Equal(t, Null[int](), obj.Age)
+
+	assert.NoError(t, SetField(&obj, "Age", Some(2)))
+	assert.Equal(t, Some(2), obj.Age)
+
+	assert.NoError(t, SetField(&obj, "Age", Some(int64(3))))
+	assert.Equal(t, Some(3), obj.Age)
+
+	assert.NoError(t, SetField(&obj, "Age", Optional[int64]{}))
+	assert.True(t, obj.Age.IsAbsent())
+
+	assert.NoError(t, SetField(&obj, "Any", nil))
+	assert.Equal(t, Null[interface{}](), obj.Any)
+
+	err := SetField(&obj, "Name", 1)
+	assert.EqualError(t, err, "Provided value type (int) didn't match obj field type (reflectme.Optional[string])")
+
+	target := TestOptionalTarget{Name: "name", Age: 1}
+	assert.NoError(t, SetField(&target, "Name", Some("some")))
+	assert.Equal(t, "some", target.Name)
+
+	assert.NoError(t, SetField(&target, "Name", Optional[string]{}))
+	assert.Equal(t, "some", target.Name)
+
+	assert.NoError(t, SetField(&target, "Age", Null[int]()))
+	assert.Equal(t, 0, target.Age)
+
+	assert.NoError(t, SetField(&target, "Nested", Some(NestedStruct{Dummy: "dummy"})))
+	assert.Equal(t, NestedStruct{Dummy: "dummy"}, target.Nested)
+}
+
+func TestIsZeroValue_on_optional(t *testing.T) {
+	assert.True(t, IsZeroValue(Optional[int]{}))
+	assert.False(t, IsZeroValue(Null[int]()))
+	assert.False(t, IsZeroValue(Some(0)))
+}
+
+func TestCopyWithOptions_on_optional(t *testing.T) {
+	from := TestOptionalStruct{Name: Some(""), Age: Null[int]()}
+	to := TestOptionalTarget{Name: "name", Age: 1, Any: "any"}
+
+	err := CopyWithOptions(from, &to, CopyOptions{CopyZeroValues: true})
+	assert.NoError(t, err)
+	assert.Equal(t, TestOptionalTarget{Any: "any"}, to)
+
+	optionalTo := TestOptionalStruct{Name: Some("name"), Any: Some[interface{}](1)}
+	err = CopyWithOptions(TestOptionalStruct{Age: Null[int]()}, &optionalTo, CopyOptions{CopyZeroValues: true})
+	assert.NoError(t, err)
+	assert.Equal(t, TestOptionalStruct{Name: Some("name"), Age: Null[int](), Any: Some[interface{}](1)}, optionalTo)
+
+	err = CopyWithOptions(TestOptionalTarget{Name: "name", Age: 2}, &optionalTo, CopyOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, TestOptionalStruct{Name: Some("name"), Age: Some(2), Any: Some[interface{}](1)}, optionalTo)
+
+	m := map[string]interface{}{}
+	err = CopyWithOptions(TestOptionalStruct{Name: Some("name"), Age: Null[int]()}, &m, CopyOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"Name": "name", "Age": nil}, m)
+
+	optionalTo = TestOptionalStruct{}
+	err = CopyWithOptions(map[string]interface{}{"Age": float64(3), "Name": nil}, &optionalTo, CopyOptions{CopyZeroValues: true})
+	assert.NoError(t, err)
+	assert.Equal(t, TestOptionalStruct{Name: Null[string](), Age: Some(3)}, optionalTo)
+}
+
+type TestOptionalPointerStruct struct {
+	Age   *Optional[int]
+	Yummy int
+}
+
+func TestGetField_on_optional_pointers(t *testing.T) {
+	age := Some(1)
+	obj := TestOptionalPointerStruct{}
+
+	value, err := GetField(obj, "Age")
+	assert.NoError(t, err)
+	assert.Equal(t, (*Optional[int])(nil), value)
+
+	obj.Age = &age
+	value, err = GetField(obj, "Age")
+	assert.NoError(t, err)
+	assert.Equal(t, &age, value)
+
+	assert.True(t, IsZeroValue((*Optional[int])(nil)))
+	assert.False(t, IsZeroValue(&age))
+}
+
+func TestSetField_on_optional_pointers(t *testing.T) {
+	age := Some(1)
+	obj := TestOptionalStruct{}
+
+	err := SetField(&obj, "Age", &age)
+	assert.Error(t, err)
+	assert.True(t, obj.Age.IsAbsent())
+
+	err = SetField(&obj, "Age", (*Optional[int])(nil))
+	assert.Error(t, err)
+	assert.True(t, obj.Age.IsAbsent())
+}
+
+func TestCopyWithOptions_on_optional_pointers(t *testing.T) {
+	age := Some(1)
+	to := TestOptionalPointerStruct{Age: &age}
+
+	report, err := CopyWithReport(TestOptionalPointerStruct{Yummy: 1}, &to, CopyOptions{CopyZeroValues: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Age", "Yummy"}, report.Copied)
+	assert.Equal(t, TestOptionalPointerStruct{Yummy: 1}, to)
+
+	report, err = CopyWithReport(TestOptionalPointerStruct{Age: &age}, &to, DefaultCopyOptions)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Age", "Yummy"}, report.Copied)
+	assert.Equal(t, TestOptionalPointerStruct{Age: &age}, to)
+}
+
+func TestApplyPatch_with_optional(t *testing.T) {
+	nickname := "nick"
+	target := TestPatchTarget{Name: "name", Age: 1, Nickname: &nickname}
+
+	err := ApplyPatch(struct {
+		Name     Optional[string]
+		Age      Optional[int]
+		Nickname Optional[string]
+		Score    Optional[float64]
+	}{Age: Some(0), Nickname: Null[string](), Score: Some(1.5)}, &target)
+	assert.NoError(t, err)
+	assert.Equal(t, TestPatchTarget{Name: "name", Score: 1.5}, target)
+
+	optionalTarget := TestOptionalStruct{Age: Some(1)}
+	age := 2
+	var null *NestedStruct
+	err = ApplyPatch(struct {
+		Name   Optional[string]
+		Age    *int
+		Nested **NestedStruct
+	}{Name: Null[string](), Age: &age, Nested: &null}, &optionalTarget)
+	assert.NoError(t, err)
+	assert.Equal(t, TestOptionalStruct{Name: Null[string](), Age: Some(2), Nested: Null[NestedStruct]()}, optionalTarget)
+
+	dummy := NestedStruct{Dummy: "dummy"}
+	err = ApplyPatch(struct{ Nested *NestedStruct }{Nested: &dummy}, &optionalTarget)
+	assert.NoError(t, err)
+	assert.Equal(t, Some(dummy), optionalTarget.Nested)
+
+	err = ApplyPatch(struct{ Name *int }{Name: &age}, &optionalTarget)
+	assert.EqualError(t, err, "Cannot patch Name: Provided value type (int) didn't match obj field type (reflectme.Optional[string])")
+}
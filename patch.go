@@ -0,0 +1,205 @@
+package reflectme
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// PatchValuer is implemented by patch field types with their own absent,
+// null and set states. PatchValue returns the value to set and if it's
+// present, with a nil value meaning null.
+type PatchValuer interface {
+	PatchValue() (interface{}, bool)
+}
+
+// ApplyPatch writes the set fields of the patch into the same paths of
+// the struct pointed by target, like a JSON PATCH DTO:
+//   - nil pointers are skipped and non-nil ones are dereferenced, so a
+//     *int sets an int or *int field, including to 0
+//   - a non-nil pointer to a nil pointer, eg a **string, sets null, which
+//     is the zero value for non-nilable fields
+//   - PatchValuer fields, like Optional, set their present values
+//   - nested structs, by value or pointer, are patched field by field
+//     unless their type matches the target one, allocating nil target
+//     pointers only when a nested field is set
+//
+// Other non-pointer fields are ignored, and so are the fields missing in
+// the target when they don't set anything. patch can whether be a structure
+// or pointer to structure.
+func ApplyPatch(patch interface{}, target interface{}) error {
+	if !hasValidType(patch, []reflect.Kind{reflect.Struct, reflect.Ptr}) {
+		return errors.New("Cannot use ApplyPatch on a non-struct interface")
+	}
+	if target == nil || !isPointer(target) || structType(reflect.TypeOf(target)) == nil || reflect.ValueOf(target).IsNil() {
+		return errors.New("Target must be a pointer to struct")
+	}
+
+	patchValue := reflect.ValueOf(patch)
+	if patchValue.Kind() == reflect.Ptr {
+		if patchValue.IsNil() {
+			return nil
+		}
+		patchValue = patchValue.Elem()
+	}
+	if patchValue.Kind() != reflect.Struct {
+		return errors.New("Cannot use ApplyPatch on a non-struct interface")
+	}
+
+	_, err := applyPatch(patchValue, reflect.ValueOf(target).Elem(), "")
+	return err
+}
+
+// applyPatch patches the target struct with the patch struct fields and
+// returns if any field was set
+func applyPatch(patch reflect.Value, target reflect.Value, parent string) (bool, error) {
+	changed := false
+	t := patch.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !isExportableField(field) {
+			continue
+		}
+
+		path := parent + field.Name
+		targetField, ok := target.Type().FieldByName(field.Name)
+		if !ok || !isExportableField(targetField) {
+			if isPatchSet(patch.Field(i)) {
+				return changed, fmt.Errorf("No such field: %s in obj", path)
+			}
+			continue
+		}
+
+		set, err := applyPatchField(patch.Field(i), target.FieldByIndex(targetField.Index), path, false)
+		if err != nil {
+			return changed, err
+		}
+		changed = changed || set
+	}
+
+	return changed, nil
+}
+
+// applyPatchField patches the target with v. Non-pointer values other
+// than structs are only set once dereferenced.
+func applyPatchField(v reflect.Value, target reflect.Value, path string, deref bool) (bool, error) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return false, nil
+		}
+	}
+
+	if valuer, ok := v.Interface().(PatchValuer); ok {
+		value, present := valuer.PatchValue()
+		if !present {
+			return false, nil
+		}
+		if value == nil {
+			return true, setPatchNull(target)
+		}
+		return applyPatchField(reflect.ValueOf(value), target, path, true)
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		elem := v.Elem()
+		if elem.Kind() == reflect.Ptr && elem.IsNil() {
+			return true, setPatchNull(target)
+		}
+		return applyPatchField(elem, target, path, true)
+	case reflect.Struct:
+		if _, err := convertValue(v.Interface(), indirectType(target.Type())); err != nil && !isOptionalType(target.Type()) {
+			return applyNestedPatch(v, target, path)
+		}
+	}
+
+	if !deref {
+		return false, nil
+	}
+
+	return true, setPatchValue(v, target, path)
+}
+
+// isPatchSet indicates if v sets any value, so its target field must exist
+func isPatchSet(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return !v.IsNil()
+	case reflect.Struct:
+		if valuer, ok := v.Interface().(PatchValuer); ok {
+			_, present := valuer.PatchValue()
+			return present
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if isExportableField(v.Type().Field(i)) && isPatchSet(v.Field(i)) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// applyNestedPatch patches the target struct, or pointer to struct, with
+// the v struct fields
+func applyNestedPatch(v reflect.Value, target reflect.Value, path string) (bool, error) {
+	if target.Kind() != reflect.Ptr {
+		if target.Kind() != reflect.Struct {
+			return false, fmt.Errorf("Cannot patch %s: %v is not a struct", path, target.Type())
+		}
+		return applyPatch(v, target, path+".")
+	}
+
+	if target.Type().Elem().Kind() != reflect.Struct {
+		return false, fmt.Errorf("Cannot patch %s: %v is not a struct", path, target.Type())
+	}
+	elem := target
+	if target.IsNil() {
+		elem = reflect.New(target.Type().Elem())
+	}
+	changed, err := applyPatch(v, elem.Elem(), path+".")
+	if changed && target.IsNil() {
+		target.Set(elem)
+	}
+
+	return changed, err
+}
+
+// setPatchNull sets the target to null, which is the zero value unless
+// it's an Optional
+func setPatchNull(target reflect.Value) error {
+	if isOptionalType(target.Type()) {
+		return setOptionalField(target, nil)
+	}
+	target.Set(reflect.Zero(target.Type()))
+
+	return nil
+}
+
+// setPatchValue sets v into the target, allocating a new pointer when
+// the target is a pointer to v type
+func setPatchValue(v reflect.Value, target reflect.Value, path string) error {
+	if isOptionalType(target.Type()) {
+		if err := setOptionalField(target, v.Interface()); err != nil {
+			return fmt.Errorf("Cannot patch %s: %v", path, err)
+		}
+		return nil
+	}
+	if target.Kind() == reflect.Ptr && !v.Type().AssignableTo(target.Type()) {
+		elem := reflect.New(target.Type().Elem())
+		if err := setPatchValue(v, elem.Elem(), path); err != nil {
+			return err
+		}
+		target.Set(elem)
+		return nil
+	}
+
+	converted, err := convertValue(v.Interface(), target.Type())
+	if err != nil {
+		return fmt.Errorf("Cannot patch %s: %v", path, err)
+	}
+	target.Set(converted)
+
+	return nil
+}
@@ -0,0 +1,200 @@
+package reflectme
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TestPatchTarget struct {
+	Name      string
+	Age       int
+	Score     float64
+	Nickname  *string
+	Labels    []string
+	CreatedAt time.Time
+	Address   *TestNestedStruct
+	Inner     TestNestedStruct
+	Nested    *NestedStruct
+	Any       interface{}
+}
+
+type TestPatchNested struct {
+	Dummy *string
+	Yummy *int
+}
+
+type TestPatchInner struct {
+	Nested *TestPatchNested
+}
+
+type TestPatchDTO struct {
+	Name      *string
+	Age       *int
+	Score     *int
+	Nickname  **string
+	Labels    *[]string
+	CreatedAt *time.Time
+	Address   *TestPatchInner
+	Inner     TestPatchInner
+	Nested    *NestedStruct
+	Any       interface{}
+	Ignored   string `json:"-"`
+}
+
+type TestPatchValue struct {
+	value   interface{}
+	present bool
+}
+
+func (v TestPatchValue) PatchValue() (interface{}, bool) {
+	return v.value, v.present
+}
+
+type TestPatchValuerDTO struct {
+	Name     TestPatchValue
+	Age      TestPatchValue
+	Nickname TestPatchValue
+}
+
+type TestPatchMissingDTO struct {
+	Obladioblada *string
+}
+
+func TestApplyPatch(t *testing.T) {
+	nickname := "nick"
+	target := TestPatchTarget{
+		Name:     "name",
+		Age:      10,
+		Score:    1.5,
+		Nickname: &nickname,
+		Labels:   []string{"a"},
+		Inner:    TestNestedStruct{Dummy: "dummy"},
+	}
+	name, age, score, yummy := "patched", 0, 3, 7
+	createdAt := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	labels := []string{"b"}
+	var null *string
+
+	err := ApplyPatch(&TestPatchDTO{
+		Name:      &name,
+		Age:       &age,
+		Score:     &score,
+		Nickname:  &null,
+		Labels:    &labels,
+		CreatedAt: &createdAt,
+		Address:   &TestPatchInner{Nested: &TestPatchNested{Yummy: &yummy}},
+		Inner:     TestPatchInner{Nested: &TestPatchNested{Dummy: &name}},
+		Nested:    &NestedStruct{Dummy: "nested"},
+		Any:       &yummy,
+		Ignored:   "ignored",
+	}, &target)
+	assert.NoError(t, err)
+	assert.Equal(t, TestPatchTarget{
+		Name:      "patched",
+		Age:       0,
+		Score:     3,
+		Labels:    []string{"b"},
+		CreatedAt: createdAt,
+		Address:   &TestNestedStruct{Nested: NestedStruct{Yummy: 7}},
+		Inner:     TestNestedStruct{Dummy: "dummy", Nested: NestedStruct{Dummy: "patched"}},
+		Nested:    &NestedStruct{Dummy: "nested"},
+		Any:       7,
+	}, target)
+	assert.Equal(t, "nick", nickname)
+}
+
+func TestApplyPatch_skips_nil_fields(t *testing.T) {
+	nickname := "nick"
+	target := TestPatchTarget{Name: "name", Nickname: &nickname}
+
+	err := ApplyPatch(TestPatchDTO{Address: &TestPatchInner{Nested: &TestPatchNested{}}}, &target)
+	assert.NoError(t, err)
+	assert.Equal(t, TestPatchTarget{Name: "name", Nickname: &nickname}, target)
+
+	err = ApplyPatch((*TestPatchDTO)(nil), &target)
+	assert.NoError(t, err)
+	assert.Equal(t, TestPatchTarget{Name: "name", Nickname: &nickname}, target)
+
+	patched := "patched"
+	pointer := &patched
+	err = ApplyPatch(TestPatchDTO{Nickname: &pointer}, &target)
+	assert.NoError(t, err)
+	assert.Equal(t, "patched", *target.Nickname)
+	assert.NotSame(t, &patched, target.Nickname)
+	assert.Equal(t, "nick", nickname)
+
+	err = ApplyPatch(struct {
+		Name   string
+		secret *string
+	}{Name: "ignored", secret: &patched}, &target)
+	assert.NoError(t, err)
+	assert.Equal(t, "name", target.Name)
+}
+
+func TestApplyPatch_with_patch_valuer(t *testing.T) {
+	nickname := "nick"
+	target := TestPatchTarget{Name: "name", Age: 10, Nickname: &nickname}
+
+	err := ApplyPatch(TestPatchValuerDTO{
+		Name:     TestPatchValue{value: nil, present: true},
+		Age:      TestPatchValue{value: 0, present: false},
+		Nickname: TestPatchValue{value: "patched", present: true},
+	}, &target)
+	assert.NoError(t, err)
+	assert.Equal(t, "", target.Name)
+	assert.Equal(t, 10, target.Age)
+	assert.Equal(t, "patched", *target.Nickname)
+}
+
+func TestApplyPatch_on_errors(t *testing.T) {
+	name := "name"
+	target := TestPatchTarget{}
+
+	err := ApplyPatch(TestPatchDTO{}, target)
+	assert.EqualError(t, err, "Target must be a pointer to struct")
+
+	err = ApplyPatch(TestPatchDTO{}, (*TestPatchTarget)(nil))
+	assert.EqualError(t, err, "Target must be a pointer to struct")
+
+	err = ApplyPatch(&name, &target)
+	assert.EqualError(t, err, "Cannot use ApplyPatch on a non-struct interface")
+
+	err = ApplyPatch(nil, &target)
+	assert.EqualError(t, err, "Cannot use ApplyPatch on a non-struct interface")
+
+	err = ApplyPatch(TestPatchMissingDTO{Obladioblada: &name}, &target)
+	assert.EqualError(t, err, "No such field: Obladioblada in obj")
+
+	err = ApplyPatch(TestPatchDTO{Name: &name}, &TestDummyOnlyStruct{})
+	assert.EqualError(t, err, "No such field: Name in obj")
+
+	err = ApplyPatch(TestPatchDTO{Inner: TestPatchInner{Nested: &TestPatchNested{}}}, &TestDummyOnlyStruct{})
+	assert.EqualError(t, err, "No such field: Inner in obj")
+
+	err = ApplyPatch(TestPatchValuerDTO{Age: TestPatchValue{present: true}}, &TestDummyOnlyStruct{})
+	assert.EqualError(t, err, "No such field: Age in obj")
+
+	err = ApplyPatch(TestPatchDTO{Ignored: "ignored"}, &TestDummyOnlyStruct{})
+	assert.NoError(t, err)
+
+	err = ApplyPatch(TestPatchValuerDTO{Age: TestPatchValue{value: "a", present: true}}, &target)
+	assert.EqualError(t, err, "Cannot patch Age: Provided value type (string) didn't match type (int)")
+
+	err = ApplyPatch(TestPatchValuerDTO{Nickname: TestPatchValue{value: 1, present: true}}, &target)
+	assert.EqualError(t, err, "Cannot patch Nickname: Provided value type (int) didn't match type (string)")
+}
+
+type TestPatchNotStructTarget struct {
+	Address *string
+	Inner   string
+}
+
+func TestApplyPatch_on_nested_errors(t *testing.T) {
+	err := ApplyPatch(TestPatchDTO{Address: &TestPatchInner{}}, &TestPatchNotStructTarget{})
+	assert.EqualError(t, err, "Cannot patch Address: *string is not a struct")
+
+	err = ApplyPatch(struct{ Inner TestPatchInner }{}, &TestPatchNotStructTarget{})
+	assert.EqualError(t, err, "Cannot patch Inner: string is not a struct")
+}
@@ -0,0 +1,221 @@
+package reflectme
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type (
+	// PathStatus indicates how a path resolves on an obj
+	PathStatus int
+)
+
+const (
+	// PathInvalid means the path doesn't exist for the obj type
+	PathInvalid PathStatus = iota
+	// PathUnresolved means the path is valid for the obj type but doesn't
+	// resolve on its value, eg a nil pointer in the middle, a missing map
+	// key or an index out of range
+	PathUnresolved
+	// PathResolved means GetField resolves the path on the obj value
+	PathResolved
+)
+
+// HasPath checks if the provided path is part of obj using the same
+// resolution as GetField: dotted field names, method calls like
+// "Total()", and index segments like "Items[0]" or "Labels[key]". obj can
+// whether be a structure or pointer to structure, including a nil pointer.
+func HasPath(obj interface{}, path string) (PathStatus, error) {
+	if !hasValidType(obj, []reflect.Kind{reflect.Struct, reflect.Ptr}) {
+		return PathInvalid, errors.New("Cannot use HasPath on a non-struct interface")
+	}
+
+	if _, err := resolveTypePath(reflect.TypeOf(obj), path, DefaultPathOptions); err != nil {
+		return PathInvalid, nil
+	}
+	if _, err := getInnerField(obj, path); err != nil {
+		return PathUnresolved, nil
+	}
+
+	return PathResolved, nil
+}
+
+// String returns the PathStatus name
+func (s PathStatus) String() string {
+	switch s {
+	case PathUnresolved:
+		return "PathUnresolved"
+	case PathResolved:
+		return "PathResolved"
+	default:
+		return "PathInvalid"
+	}
+}
+
+// resolveTypePath returns the type the path resolves to from t, without
+// needing a value. Interface types can't be checked further, so any
+// path below them is valid.
+func resolveTypePath(t reflect.Type, path string, options PathOptions) (reflect.Type, error) {
+	if path == "" || strings.HasSuffix(path, ".") {
+		return nil, fmt.Errorf("Empty segment in path: %s", path)
+	}
+	fullPath := path
+	for len(path) > 0 {
+		currName, nextFieldName := getCurrAndNextFieldName(path)
+		name, indexes, err := splitPathIndexes(currName)
+		if err != nil {
+			return nil, err
+		}
+		if name == "" {
+			return nil, fmt.Errorf("Empty segment in path: %s", fullPath)
+		}
+
+		t = indirectType(t)
+		if t.Kind() == reflect.Interface {
+			return t, nil
+		}
+		if options.AllowMethodCalls && isMethodCall(name) {
+			methodName := strings.TrimSuffix(name, "()")
+			method := reflect.Zero(reflect.PtrTo(t)).MethodByName(methodName)
+			if !method.IsValid() {
+				return nil, fmt.Errorf("No such method: %s in obj", methodName)
+			}
+			if err := checkPathMethod(methodName, method.Type()); err != nil {
+				return nil, err
+			}
+			t = method.Type().Out(0)
+		} else {
+			if t.Kind() != reflect.Struct {
+				return nil, fmt.Errorf("Not a struct: %s in obj", path)
+			}
+			field, ok := t.FieldByName(name)
+			if !ok || !isExportableField(field) {
+				return nil, fmt.Errorf("No such field: %s in obj", path)
+			}
+			t = field.Type
+		}
+
+		for _, index := range indexes {
+			t = indirectType(t)
+			switch t.Kind() {
+			case reflect.Interface:
+				return t, nil
+			case reflect.Slice, reflect.Array:
+				i, err := strconv.Atoi(index)
+				if err != nil || i < 0 || (t.Kind() == reflect.Array && i >= t.Len()) {
+					return nil, fmt.Errorf("Invalid index: %s in obj", path)
+				}
+			case reflect.Map:
+				if _, err := pathMapKey(index, t.Key()); err != nil {
+					return nil, err
+				}
+			default:
+				return nil, fmt.Errorf("Not a collection: %s in obj", path)
+			}
+			t = t.Elem()
+		}
+		path = nextFieldName
+	}
+
+	return t, nil
+}
+
+// splitPathIndexes splits a path segment like "Items[0][key]" in its
+// name and index parts
+func splitPathIndexes(segment string) (string, []string, error) {
+	i := strings.Index(segment, "[")
+	if i == -1 {
+		return segment, nil, nil
+	}
+
+	name := segment[0:i]
+	var indexes []string
+	rest := segment[i:]
+	for len(rest) > 0 {
+		end := strings.Index(rest, "]")
+		if rest[0] != '[' || end == -1 {
+			return "", nil, fmt.Errorf("Invalid index segment: %s", segment)
+		}
+		indexes = append(indexes, rest[1:end])
+		rest = rest[end+1:]
+	}
+
+	return name, indexes, nil
+}
+
+// indexPathValue applies the index segments to v
+func indexPathValue(v reflect.Value, indexes []string, fullName string) (reflect.Value, error) {
+	for _, index := range indexes {
+		for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return reflect.Value{}, fmt.Errorf("Nil pointer: %s in obj", fullName)
+			}
+			v = v.Elem()
+		}
+
+		switch v.Kind() {
+		case reflect.Slice, reflect.Array:
+			i, err := strconv.Atoi(index)
+			if err != nil || i < 0 || i >= v.Len() {
+				return reflect.Value{}, fmt.Errorf("Index out of range: %s in obj", fullName)
+			}
+			v = v.Index(i)
+		case reflect.Map:
+			key, err := pathMapKey(index, v.Type().Key())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			elem := v.MapIndex(key)
+			if !elem.IsValid() {
+				return reflect.Value{}, fmt.Errorf("No such key: %s in obj", fullName)
+			}
+			v = elem
+		default:
+			return reflect.Value{}, fmt.Errorf("Not a collection: %s in obj", fullName)
+		}
+	}
+
+	return v, nil
+}
+
+// pathMapKey converts an index segment to a map key of type t
+func pathMapKey(index string, t reflect.Type) (reflect.Value, error) {
+	key := reflect.New(t).Elem()
+	switch {
+	case t.Kind() == reflect.String:
+		key.SetString(index)
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Int64:
+		n, err := strconv.ParseInt(index, 10, t.Bits())
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("Invalid map key: %s", index)
+		}
+		key.SetInt(n)
+	case t.Kind() >= reflect.Uint && t.Kind() <= reflect.Uintptr:
+		n, err := strconv.ParseUint(index, 10, t.Bits())
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("Invalid map key: %s", index)
+		}
+		key.SetUint(n)
+	case t.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(index)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("Invalid map key: %s", index)
+		}
+		key.SetBool(b)
+	default:
+		return reflect.Value{}, fmt.Errorf("Unsupported map key type: %v", t)
+	}
+
+	return key, nil
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return t
+}
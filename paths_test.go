@@ -0,0 +1,163 @@
+package reflectme
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TestPathsStruct struct {
+	Items   []NestedStruct
+	Array   [2]*NestedStruct
+	ByName  map[string]*NestedStruct
+	ByID    map[int64]NestedStruct
+	ByUint  map[uint]int
+	ByFlag  map[bool]string
+	ByFloat map[float64]string
+	Matrix  [][]int
+	Any     interface{}
+	Inner   *TestMethodPathStruct
+	Value   int
+}
+
+func TestGetField_with_index_segments(t *testing.T) {
+	dummyStruct := TestPathsStruct{
+		Items:  []NestedStruct{{Dummy: "i0"}, {Dummy: "i1"}},
+		Array:  [2]*NestedStruct{{Dummy: "a0"}},
+		ByName: map[string]*NestedStruct{"a.b": {Dummy: "n"}},
+		ByID:   map[int64]NestedStruct{-7: {Yummy: 7}},
+		ByUint: map[uint]int{3: 3},
+		ByFlag: map[bool]string{true: "yes"},
+		Matrix: [][]int{{1, 2}},
+		Any:    []string{"x"},
+	}
+
+	cases := map[string]interface{}{
+		"Items[1].Dummy":    "i1",
+		"Items[0]":          NestedStruct{Dummy: "i0"},
+		"Array[0].Dummy":    "a0",
+		"ByName[a.b].Dummy": "n",
+		"ByID[-7].Yummy":    7,
+		"ByUint[3]":         3,
+		"ByFlag[true]":      "yes",
+		"Matrix[0][1]":      2,
+		"Any[0]":            "x",
+	}
+	for path, expected := range cases {
+		value, err := GetField(dummyStruct, path)
+		assert.NoError(t, err, path)
+		assert.Equal(t, expected, value, path)
+	}
+}
+
+func TestGetField_with_invalid_index_segments(t *testing.T) {
+	dummyStruct := &TestPathsStruct{
+		Items:   []NestedStruct{{}},
+		ByName:  map[string]*NestedStruct{},
+		ByID:    map[int64]NestedStruct{},
+		ByUint:  map[uint]int{},
+		ByFlag:  map[bool]string{},
+		ByFloat: map[float64]string{},
+	}
+
+	paths := []string{
+		"Items[1]",
+		"Items[a]",
+		"Items[0",
+		"Items[0]x",
+		"Array[0].Dummy",
+		"ByName[a]",
+		"ByID[a]",
+		"ByUint[-1]",
+		"ByFlag[maybe]",
+		"ByFloat[1.5]",
+		"Value[0]",
+		"Any[0]",
+		"Obladioblada[0]",
+	}
+	for _, path := range paths {
+		_, err := GetField(dummyStruct, path)
+		assert.Error(t, err, path)
+	}
+
+	_, err := GetFieldTag(dummyStruct, "Items[0]", "test")
+	assert.Error(t, err)
+
+	tag, err := GetFieldTag(dummyStruct, "Items[0].Dummy", "test")
+	assert.NoError(t, err)
+	assert.Equal(t, "dummytag", tag)
+}
+
+func TestHasPath(t *testing.T) {
+	dummyStruct := TestPathsStruct{
+		Items:  []NestedStruct{{}},
+		ByName: map[string]*NestedStruct{"a": {}},
+		Inner:  &TestMethodPathStruct{},
+	}
+
+	cases := map[string]PathStatus{
+		"Value":                       PathResolved,
+		"Items":                       PathResolved,
+		"Items[0].Dummy":              PathResolved,
+		"ByName[a].Yummy":             PathResolved,
+		"Inner.FullName()":            PathResolved,
+		"Inner.NestedValue().Dummy":   PathResolved,
+		"Any.Whatever":                PathUnresolved,
+		"Items[1].Dummy":              PathUnresolved,
+		"ByName[b].Dummy":             PathUnresolved,
+		"Array[1].Dummy":              PathUnresolved,
+		"Inner.Nested.Dummy":          PathUnresolved,
+		"Inner.NestedPointer().Dummy": PathUnresolved,
+		"Obladioblada":                PathInvalid,
+		"Items[0].Obladioblada":       PathInvalid,
+		"Items[a]":                    PathInvalid,
+		"Items[0":                     PathInvalid,
+		"Array[2]":                    PathInvalid,
+		"ByID[a]":                     PathInvalid,
+		"ByID[1]":                     PathUnresolved,
+		"Matrix[0][0]":                PathUnresolved,
+		"Value[0]":                    PathInvalid,
+		"Value.Dummy":                 PathInvalid,
+		"Inner.WithArg()":             PathInvalid,
+		"Inner.Obladioblada()":        PathInvalid,
+		"Inner.unexported":            PathInvalid,
+		"Any[0].Dummy":                PathUnresolved,
+		"":                            PathInvalid,
+		"Items[0].":                   PathInvalid,
+		"Inner..Value":                PathInvalid,
+		".Value":                      PathInvalid,
+		"[0]":                         PathInvalid,
+	}
+	for path, expected := range cases {
+		status, err := HasPath(dummyStruct, path)
+		assert.NoError(t, err, path)
+		assert.Equal(t, expected, status, path)
+	}
+}
+
+func TestHasPath_on_nil_root(t *testing.T) {
+	status, err := HasPath((*TestPathsStruct)(nil), "Items[0].Dummy")
+	assert.NoError(t, err)
+	assert.Equal(t, PathUnresolved, status)
+
+	status, err = HasPath((*TestPathsStruct)(nil), "Obladioblada")
+	assert.NoError(t, err)
+	assert.Equal(t, PathInvalid, status)
+
+	_, err = HasPath(nil, "Items")
+	assert.Error(t, err)
+}
+
+func TestHasPath_on_empty_path(t *testing.T) {
+	i := 1
+
+	status, err := HasPath(&i, "")
+	assert.NoError(t, err)
+	assert.Equal(t, PathInvalid, status)
+}
+
+func TestPathStatus_String(t *testing.T) {
+	assert.Equal(t, "PathInvalid", PathInvalid.String())
+	assert.Equal(t, "PathUnresolved", PathUnresolved.String())
+	assert.Equal(t, "PathResolved", PathResolved.String())
+}
@@ -0,0 +1,451 @@
+package reflectme
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"sort"
+	"time"
+)
+
+type (
+	// CheckOptions are options for check function
+	CheckOptions struct {
+		// Runs is the number of generated values the property is checked
+		// with. Zero means DefaultCheckOptions.Runs.
+		Runs int
+		// Seed seeds the generated values, 0 means a time based seed.
+		// The seed is reported on failures to reproduce them.
+		Seed int64
+		// MaxShrinks is the maximum number of property calls made to
+		// shrink a failing value. Zero means DefaultCheckOptions.MaxShrinks
+		// and a negative value disables shrinking.
+		MaxShrinks int
+		// Fill are the options of the generated values. Their Seed is
+		// ignored.
+		Fill FillOptions
+	}
+
+	// TestingT is the part of testing.T that Check uses
+	TestingT interface {
+		Helper()
+		Fatalf(format string, args ...interface{})
+	}
+
+	// Generator generates arbitrary T values and shrinks them
+	Generator[T any] struct {
+		options FillOptions
+	}
+)
+
+// DefaultCheckOptions are the default options for check function
+var DefaultCheckOptions = CheckOptions{
+	Runs:       100,
+	MaxShrinks: 1000,
+	Fill:       FillOptions{MaxItems: 5, MaxDepth: 3},
+}
+
+// Check checks property with DefaultCheckOptions
+func Check[T any](t TestingT, property func(T) bool) {
+	t.Helper()
+	CheckWithOptions(t, property, DefaultCheckOptions)
+}
+
+// CheckWithOptions checks that property holds for CheckOptions.Runs
+// arbitrary T values generated like Generator.Generate does. The first
+// value the property doesn't hold for, or panics with, is shrunk and
+// reported with GoLiteral, the seed that generated it and the panic.
+func CheckWithOptions[T any](t TestingT, property func(T) bool, options CheckOptions) {
+	t.Helper()
+	seed := options.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	if options.Runs <= 0 {
+		options.Runs = DefaultCheckOptions.Runs
+	}
+	if options.MaxShrinks == 0 {
+		options.MaxShrinks = DefaultCheckOptions.MaxShrinks
+	}
+
+	g := NewGenerator[T](options.Fill)
+	r := rand.New(rand.NewSource(seed))
+	for run := 1; run <= options.Runs; run++ {
+		value, err := g.Generate(r)
+		if err != nil {
+			t.Fatalf("Cannot generate %T: %v", value, err)
+			return
+		}
+		ok, recovered := holds(property, value)
+		if ok {
+			continue
+		}
+
+		shrunk, steps, recovered := g.shrinkFailing(value, recovered, property, options.MaxShrinks)
+		literal, err := GoLiteral(shrunk)
+		if err != nil {
+			literal = fmt.Sprintf("%#v", shrunk)
+		}
+		if recovered != nil {
+			literal += fmt.Sprintf("\npanic: %v", recovered)
+		}
+		t.Fatalf("Property failed on run %d with seed %d, shrunk in %d steps:\n%s", run, seed, steps, literal)
+		return
+	}
+}
+
+// NewGenerator returns a Generator of T values with FillOptions
+func NewGenerator[T any](options FillOptions) *Generator[T] {
+	return &Generator[T]{options: options}
+}
+
+// Generate returns an arbitrary T value from r. Values are filled like
+// Fill does, respecting the fake and validate tags, but favoring edge
+// cases: zero values, numeric bounds, unicode strings, nil pointers and
+// absent or null Optional values.
+func (g *Generator[T]) Generate(r *rand.Rand) (T, error) {
+	var value T
+	f := &filler{
+		options:   g.options,
+		rand:      r,
+		types:     make(map[reflect.Type]bool),
+		arbitrary: true,
+	}
+	err := f.fill(reflect.ValueOf(&value).Elem(), "", fillConstraint{}, 1)
+
+	return value, err
+}
+
+// Shrink returns simpler values than value, simplest first, changing one
+// field or element at a time towards its zero value while respecting
+// the fake and validate tags. Values filled by fake tags, formats or
+// FillOptions.Generators aren't shrunk.
+func (g *Generator[T]) Shrink(value T) []T {
+	candidates := g.shrinks(reflect.ValueOf(&value).Elem(), fillConstraint{})
+	values := make([]T, len(candidates))
+	for i, candidate := range candidates {
+		values[i] = candidate.Interface().(T)
+	}
+
+	return values
+}
+
+// shrinkFailing shrinks value, which panicked with recovered or nil,
+// while the property doesn't hold. It returns the simplest failing value,
+// the number of shrinks and the value panic.
+func (g *Generator[T]) shrinkFailing(value T, recovered interface{}, property func(T) bool, maxShrinks int) (T, int, interface{}) {
+	steps, calls := 0, 0
+	for {
+		shrunk := false
+		for _, candidate := range g.Shrink(value) {
+			if calls >= maxShrinks {
+				return value, steps, recovered
+			}
+			calls++
+			if ok, candidateRecovered := holds(property, candidate); !ok {
+				value, recovered, shrunk = candidate, candidateRecovered, true
+				steps++
+				break
+			}
+		}
+		if !shrunk {
+			return value, steps, recovered
+		}
+	}
+}
+
+// shrinks returns the simpler values than v for its constraint
+func (g *Generator[T]) shrinks(v reflect.Value, c fillConstraint) []reflect.Value {
+	t := v.Type()
+	leaf := isFillLeafType(t)
+	if (leaf && (c.fake != "" || c.schema.Format != "")) || g.options.Generators[t] != nil {
+		return nil
+	}
+
+	var candidates []reflect.Value
+	switch {
+	case leaf && len(c.schema.Enum) > 0:
+		candidate := reflect.New(t).Elem()
+		if setFilledValue(candidate, "", c.schema.Enum[0]) == nil && !reflect.DeepEqual(candidate.Interface(), v.Interface()) {
+			candidates = append(candidates, candidate)
+		}
+	case t == timeType:
+		if !v.IsZero() {
+			candidates = append(candidates, reflect.Zero(t))
+		}
+	case isOptionalType(t):
+		candidates = g.shrinkOptional(v, c)
+	case t.Kind() == reflect.Struct:
+		candidates = g.shrinkStruct(v)
+	default:
+		candidates = g.shrinkKind(v, c)
+	}
+
+	// Required values are never shrunk to zero
+	if c.required {
+		filtered := candidates[:0]
+		for _, candidate := range candidates {
+			if !candidate.IsZero() {
+				filtered = append(filtered, candidate)
+			}
+		}
+		candidates = filtered
+	}
+
+	return candidates
+}
+
+func (g *Generator[T]) shrinkKind(v reflect.Value, c fillConstraint) []reflect.Value {
+	t := v.Type()
+	var candidates []reflect.Value
+	switch t.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			candidates = append(candidates, reflect.Zero(t))
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		lo, hi := intRange(t, c.schema)
+		for _, n := range shrinkInt(v.Int(), max(lo, min(hi, 0))) {
+			candidates = append(candidates, reflect.ValueOf(n).Convert(t))
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		lo, hi := intRange(t, c.schema)
+		for _, n := range shrinkInt(int64(min(v.Uint(), math.MaxInt64)), max(lo, min(hi, 0))) {
+			candidates = append(candidates, reflect.ValueOf(uint64(n)).Convert(t))
+		}
+	case reflect.Float32, reflect.Float64:
+		lo, hi := floatRange(t, c.schema)
+		for _, f := range shrinkFloat(v.Float(), math.Max(lo, math.Min(hi, 0))) {
+			candidates = append(candidates, reflect.ValueOf(f).Convert(t))
+		}
+	case reflect.String:
+		runes := []rune(v.String())
+		lo := 0
+		if c.schema.MinLength != nil {
+			lo = *c.schema.MinLength
+		}
+		for _, n := range shrinkLength(len(runes), lo) {
+			candidates = append(candidates, reflect.ValueOf(string(runes[:n])).Convert(t))
+		}
+	case reflect.Ptr:
+		if v.IsNil() {
+			break
+		}
+		candidates = append(candidates, reflect.Zero(t))
+		for _, elem := range g.shrinks(v.Elem(), c) {
+			ptr := reflect.New(t.Elem())
+			ptr.Elem().Set(elem)
+			candidates = append(candidates, ptr)
+		}
+	case reflect.Slice, reflect.Array:
+		candidates = g.shrinkSequence(v, c)
+	case reflect.Map:
+		candidates = g.shrinkMap(v, c)
+	}
+
+	return candidates
+}
+
+func (g *Generator[T]) shrinkOptional(v reflect.Value, c fillConstraint) []reflect.Value {
+	t := v.Type()
+	value, state := v.Interface().(optional).optionalValue()
+	if state == optionalAbsent {
+		return nil
+	}
+
+	candidates := []reflect.Value{reflect.Zero(t)}
+	if state == optionalNull {
+		return candidates
+	}
+
+	elem := reflect.New(v.Interface().(optional).optionalElem()).Elem()
+	if value != nil {
+		elem.Set(reflect.ValueOf(value))
+	}
+	for _, shrunk := range g.shrinks(elem, c) {
+		candidate := reflect.New(t).Elem()
+		_ = setOptionalField(candidate, shrunk.Interface())
+		candidates = append(candidates, candidate)
+	}
+
+	return candidates
+}
+
+// shrinkStruct shrinks v one field at a time
+func (g *Generator[T]) shrinkStruct(v reflect.Value) []reflect.Value {
+	t := v.Type()
+	var candidates []reflect.Value
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !isExportableField(field) {
+			continue
+		}
+		c, err := fieldConstraint(field, field.Name)
+		if err != nil || c.fake == "-" {
+			continue
+		}
+
+		for _, shrunk := range g.shrinks(v.Field(i), c) {
+			candidate := reflect.New(t).Elem()
+			candidate.Set(v)
+			candidate.Field(i).Set(shrunk)
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	return candidates
+}
+
+// shrinkSequence shrinks the slice v to nil, its first half and without
+// each element, then, like arrays, one element at a time
+func (g *Generator[T]) shrinkSequence(v reflect.Value, c fillConstraint) []reflect.Value {
+	t := v.Type()
+	var candidates []reflect.Value
+	if t.Kind() == reflect.Slice {
+		if v.IsNil() {
+			return nil
+		}
+		lo := 0
+		if c.schema.MinItems != nil {
+			lo = *c.schema.MinItems
+		}
+		if lo == 0 {
+			candidates = append(candidates, reflect.Zero(t))
+		}
+		if half := v.Len() / 2; half > 0 && half >= lo && half < v.Len()-1 {
+			candidates = append(candidates, v.Slice(0, half))
+		}
+		for i := 0; i < v.Len() && v.Len() > lo; i++ {
+			candidate := reflect.MakeSlice(t, 0, v.Len()-1)
+			candidate = reflect.AppendSlice(candidate, v.Slice(0, i))
+			candidates = append(candidates, reflect.AppendSlice(candidate, v.Slice(i+1, v.Len())))
+		}
+	}
+
+	for i := 0; i < v.Len(); i++ {
+		for _, elem := range g.shrinks(v.Index(i), fillConstraint{fake: c.fake}) {
+			candidate := reflect.New(t).Elem()
+			if t.Kind() == reflect.Slice {
+				candidate.Set(reflect.MakeSlice(t, v.Len(), v.Len()))
+			}
+			reflect.Copy(candidate, v)
+			candidate.Index(i).Set(elem)
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	return candidates
+}
+
+// shrinkMap shrinks v without each key, then one value at a time, with
+// keys sorted like FieldsNames does
+func (g *Generator[T]) shrinkMap(v reflect.Value, c fillConstraint) []reflect.Value {
+	if v.IsNil() {
+		return nil
+	}
+
+	t := v.Type()
+	lo := 0
+	if c.schema.MinItems != nil {
+		lo = *c.schema.MinItems
+	}
+	keys := v.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+	})
+
+	copyMap := func() reflect.Value {
+		m := reflect.MakeMapWithSize(t, v.Len())
+		for _, key := range keys {
+			m.SetMapIndex(key, v.MapIndex(key))
+		}
+		return m
+	}
+
+	var candidates []reflect.Value
+	for _, key := range keys {
+		if v.Len() <= lo {
+			break
+		}
+		candidate := copyMap()
+		candidate.SetMapIndex(key, reflect.Value{})
+		candidates = append(candidates, candidate)
+	}
+	for _, key := range keys {
+		for _, elem := range g.shrinks(v.MapIndex(key), fillConstraint{fake: c.fake}) {
+			candidate := copyMap()
+			candidate.SetMapIndex(key, elem)
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	return candidates
+}
+
+// shrinkInt returns the numbers between n and target closer to target,
+// target first
+func shrinkInt(n, target int64) []int64 {
+	if n == target {
+		return nil
+	}
+
+	candidates := []int64{target}
+	if mid := target + (n-target)/2; mid != target && mid != n {
+		candidates = append(candidates, mid)
+	}
+	step := n - 1
+	if n < target {
+		step = n + 1
+	}
+	if step != target && step != candidates[len(candidates)-1] {
+		candidates = append(candidates, step)
+	}
+
+	return candidates
+}
+
+// shrinkFloat returns the numbers between f and target closer to target,
+// target first
+func shrinkFloat(f, target float64) []float64 {
+	if f == target || math.IsNaN(f) {
+		return nil
+	}
+
+	candidates := []float64{target}
+	if truncated := math.Trunc(f); truncated != f && math.Abs(truncated-target) < math.Abs(f-target) {
+		candidates = append(candidates, truncated)
+	}
+	if mid := target + (f-target)/2; mid != target && mid != f {
+		candidates = append(candidates, mid)
+	}
+
+	return candidates
+}
+
+// shrinkLength returns the lengths between n and lo, shortest first
+func shrinkLength(n, lo int) []int {
+	if n <= lo {
+		return nil
+	}
+
+	candidates := []int{lo}
+	if half := n / 2; half > lo {
+		candidates = append(candidates, half)
+	}
+	if n-1 > lo && n-1 != n/2 {
+		candidates = append(candidates, n-1)
+	}
+
+	return candidates
+}
+
+// holds indicates if property holds for value, which it doesn't when it
+// panics. The recovered panic is returned too.
+func holds[T any](property func(T) bool, value T) (ok bool, recovered interface{}) {
+	defer func() {
+		recovered = recover()
+	}()
+
+	return property(value), nil
+}
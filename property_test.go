@@ -0,0 +1,284 @@
+package reflectme
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TestPropertyItem struct {
+	SKU      string `fake:"uuid"`
+	Quantity int    `validate:"min=1,max=10"`
+}
+
+type TestPropertyStruct struct {
+	Name     string  `validate:"required,max=8"`
+	Email    string  `validate:"email"`
+	Age      uint8   `validate:"gte=18,lt=120"`
+	Score    float64 `validate:"gt=0,lte=1"`
+	Balance  int64
+	Ratio    float32
+	Role     string `validate:"oneof=admin user"`
+	Level    int    `validate:"oneof=1 2 3"`
+	Active   bool
+	Tags     []string `validate:"max=3"`
+	Items    []TestPropertyItem
+	Codes    [2]uint16
+	Labels   map[string]int   `validate:"min=1"`
+	Nickname Optional[string] `validate:"min=2"`
+	Manager  *TestPropertyStruct
+	Parent   *TestPropertyItem `validate:"required"`
+	Joined   time.Time
+	Any      interface{}
+	Skipped  string `fake:"-"`
+	internal int
+}
+
+type testingT struct {
+	failure string
+}
+
+func (t *testingT) Helper() {}
+
+func (t *testingT) Fatalf(format string, args ...interface{}) {
+	t.failure = fmt.Sprintf(format, args...)
+}
+
+func TestCheck(t *testing.T) {
+	// Generated values respect the fake and validate tags
+	Check(t, func(obj TestPropertyStruct) bool {
+		runes := utf8.RuneCountInString(obj.Name)
+		return runes >= 1 && runes <= 8 &&
+			obj.Age >= 18 && obj.Age < 120 &&
+			obj.Score > 0 && obj.Score <= 1 &&
+			(obj.Role == "admin" || obj.Role == "user") &&
+			obj.Level >= 1 && obj.Level <= 3 &&
+			len(obj.Tags) <= 3 && len(obj.Labels) >= 1 && obj.Parent != nil &&
+			obj.Skipped == "" && obj.internal == 0
+	})
+
+	var ft testingT
+	Check(&ft, func(obj TestPropertyStruct) bool { return true })
+	assert.Empty(t, ft.failure)
+}
+
+func TestCheckWithOptions(t *testing.T) {
+	options := DefaultCheckOptions
+	options.Seed = 1
+
+	var ft testingT
+	CheckWithOptions(&ft, func(obj TestPropertyStruct) bool {
+		return obj.Age < 30 || len(obj.Items) < 2
+	}, options)
+	assert.Regexp(t, `^Property failed on run \d+ with seed 1, shrunk in \d+ steps:\n`, ft.failure)
+	assert.Regexp(t, `\n\tAge: +30,\n`, ft.failure)
+	assert.Regexp(t, `\n\tItems: \[\]reflectme.TestPropertyItem\{\n(\t\t\{SKU: "[0-9a-f-]+", Quantity: 1\},\n){2}\t\},\n`, ft.failure)
+	assert.NotContains(t, ft.failure, "Manager")
+	assert.NotContains(t, ft.failure, "Balance")
+
+	// Equal seeds report equal failures
+	var other testingT
+	CheckWithOptions(&other, func(obj TestPropertyStruct) bool {
+		return obj.Age < 30 || len(obj.Items) < 2
+	}, options)
+	assert.Equal(t, ft.failure, other.failure)
+
+	// Panics are failures
+	CheckWithOptions(&ft, func(n int) bool {
+		if n > 5 {
+			panic("too big")
+		}
+		return true
+	}, options)
+	assert.Regexp(t, `^Property failed on run \d+ with seed 1, shrunk in \d+ steps:\n6\npanic: too big$`, ft.failure)
+
+	CheckWithOptions(&ft, func(s string) bool {
+		return utf8.RuneCountInString(s) < 3
+	}, options)
+	assert.Regexp(t, `shrunk in \d+ steps:\n"...?.?.?.?.?.?.?.?.?.?"$`, ft.failure)
+
+	// Shrinking stops after MaxShrinks property calls
+	options.MaxShrinks = -1
+	CheckWithOptions(&ft, func(obj TestPropertyStruct) bool { return false }, options)
+	assert.Regexp(t, `^Property failed on run 1 with seed 1, shrunk in 0 steps:\n`, ft.failure)
+}
+
+func TestCheckWithOptions_on_zero_options(t *testing.T) {
+	var ft testingT
+	runs := 0
+	CheckWithOptions(&ft, func(n int) bool {
+		runs++
+		return true
+	}, CheckOptions{Seed: 1})
+	assert.Empty(t, ft.failure)
+	assert.Equal(t, DefaultCheckOptions.Runs, runs)
+
+	CheckWithOptions(&ft, func(n int) bool { return n < 5 || n > 1000 }, CheckOptions{Seed: 1})
+	assert.Regexp(t, `shrunk in [1-9]\d* steps:\n5$`, ft.failure)
+}
+
+func TestCheckWithOptions_on_errors(t *testing.T) {
+	var ft testingT
+	CheckWithOptions(&ft, func(obj struct {
+		Value int `validate:"min=a"`
+	}) bool {
+		return true
+	}, DefaultCheckOptions)
+	assert.Equal(t, `Cannot generate struct { Value int "validate:\"min=a\"" }: Invalid validate tag on field Value: strconv.ParseFloat: parsing "a": invalid syntax`, ft.failure)
+
+	// Values GoLiteral can't render are reported with their Go syntax
+	options := DefaultCheckOptions
+	options.Fill.Generators = map[reflect.Type]FakeGenerator{
+		reflect.TypeOf(func() {}): func(r *rand.Rand) interface{} { return func() {} },
+	}
+	CheckWithOptions(&ft, func(f func()) bool { return false }, options)
+	assert.Regexp(t, `shrunk in 0 steps:\n\(func\(\)\)\(0x[0-9a-f]+\)$`, ft.failure)
+}
+
+func TestGenerator_Generate(t *testing.T) {
+	g := NewGenerator[TestPropertyStruct](FillOptions{MaxItems: 5, MaxDepth: 2})
+	r := rand.New(rand.NewSource(1))
+	var zeroAge, nilManager, absentNickname, nullNickname, zeroJoined bool
+	for i := 0; i < 200; i++ {
+		obj, err := g.Generate(r)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, obj.Name)
+		assert.Regexp(t, `^[a-z]+\.[a-z]+\d+@example\.com$`, obj.Email)
+		assert.Len(t, obj.SKUs(), len(obj.Items))
+		if name, ok := obj.Nickname.Get(); ok {
+			assert.True(t, utf8.RuneCountInString(name) >= 2)
+		}
+		if obj.Manager != nil {
+			assert.Nil(t, obj.Manager.Manager)
+		}
+		zeroAge = zeroAge || obj.Age == 18
+		nilManager = nilManager || obj.Manager == nil
+		absentNickname = absentNickname || obj.Nickname.IsAbsent()
+		nullNickname = nullNickname || obj.Nickname.IsNull()
+		zeroJoined = zeroJoined || obj.Joined.IsZero()
+	}
+
+	// Edge cases are generated
+	assert.True(t, zeroAge)
+	assert.True(t, nilManager)
+	assert.True(t, absentNickname)
+	assert.True(t, nullNickname)
+	assert.True(t, zeroJoined)
+
+	n, err := NewGenerator[*int64](FillOptions{}).Generate(rand.New(rand.NewSource(1)))
+	assert.NoError(t, err)
+	assert.NotNil(t, n)
+}
+
+func (obj TestPropertyStruct) SKUs() []string {
+	skus := make([]string, 0, len(obj.Items))
+	for _, item := range obj.Items {
+		skus = append(skus, item.SKU)
+	}
+
+	return skus
+}
+
+func TestGenerator_Shrink(t *testing.T) {
+	g := NewGenerator[TestPropertyStruct](DefaultFillOptions)
+	obj := TestPropertyStruct{
+		Name:   "ab",
+		Age:    20,
+		Score:  math.SmallestNonzeroFloat64,
+		Role:   "user",
+		Level:  1,
+		Parent: &TestPropertyItem{Quantity: 1},
+	}
+	assert.Equal(t, []TestPropertyStruct{
+		{Name: "a", Age: 20, Score: obj.Score, Role: "user", Level: 1, Parent: obj.Parent},
+		{Name: "ab", Age: 18, Score: obj.Score, Role: "user", Level: 1, Parent: obj.Parent},
+		{Name: "ab", Age: 19, Score: obj.Score, Role: "user", Level: 1, Parent: obj.Parent},
+		{Name: "ab", Age: 20, Score: obj.Score, Role: "admin", Level: 1, Parent: obj.Parent},
+	}, g.Shrink(obj))
+
+	ints := NewGenerator[[]int](DefaultFillOptions)
+	assert.Equal(t, [][]int{nil, {1}, {2, 3}, {1, 3}, {1, 2}, {0, 2, 3}, {1, 0, 3}, {1, 1, 3}, {1, 2, 0}, {1, 2, 1}, {1, 2, 2}},
+		ints.Shrink([]int{1, 2, 3}))
+	assert.Equal(t, [][]int{nil, {0, 0}, {0, 0, 0}, {0, 0, 0}, {0, 0, 0}}, ints.Shrink([]int{0, 0, 0, 0})[:5])
+	assert.Empty(t, ints.Shrink(nil))
+
+	maps := NewGenerator[map[string]int](DefaultFillOptions)
+	assert.Equal(t, []map[string]int{{"b": 0}, {"a": 1}, {"a": 0, "b": 0}}, maps.Shrink(map[string]int{"a": 1, "b": 0}))
+	assert.Empty(t, maps.Shrink(nil))
+
+	arrays := NewGenerator[[2]bool](DefaultFillOptions)
+	assert.Equal(t, [][2]bool{{false, true}, {true, false}}, arrays.Shrink([2]bool{true, true}))
+
+	floats := NewGenerator[float64](DefaultFillOptions)
+	assert.Equal(t, []float64{0, 7, 3.75}, floats.Shrink(7.5))
+	assert.Equal(t, []float64{0, -2}, floats.Shrink(-4))
+	assert.Empty(t, floats.Shrink(math.NaN()))
+
+	uints := NewGenerator[uint](DefaultFillOptions)
+	assert.Equal(t, []uint{0, 50, 99}, uints.Shrink(100))
+	assert.Equal(t, []uint{0}, uints.Shrink(1))
+	assert.Equal(t, []int{0, -1}, NewGenerator[int](DefaultFillOptions).Shrink(-2))
+
+	pointers := NewGenerator[*string](DefaultFillOptions)
+	s := "ab"
+	shrunk := pointers.Shrink(&s)
+	assert.Len(t, shrunk, 3)
+	assert.Nil(t, shrunk[0])
+	assert.Equal(t, "", *shrunk[1])
+	assert.Equal(t, "a", *shrunk[2])
+
+	optionals := NewGenerator[Optional[bool]](DefaultFillOptions)
+	assert.Equal(t, []Optional[bool]{{}, Some(false)}, optionals.Shrink(Some(true)))
+	assert.Equal(t, []Optional[bool]{{}}, optionals.Shrink(Null[bool]()))
+	assert.Empty(t, optionals.Shrink(Optional[bool]{}))
+	assert.Equal(t, []Optional[interface{}]{{}}, NewGenerator[Optional[interface{}]](DefaultFillOptions).Shrink(Some[interface{}](nil)))
+
+	times := NewGenerator[time.Time](DefaultFillOptions)
+	assert.Equal(t, []time.Time{{}}, times.Shrink(time.Now()))
+
+	// Fake tags, formats and generated types aren't shrunk
+	assert.Empty(t, NewGenerator[TestPropertyItem](DefaultFillOptions).Shrink(TestPropertyItem{SKU: "sku", Quantity: 1}))
+	assert.Empty(t, NewGenerator[struct {
+		Email string `validate:"email"`
+		Bad   int    `validate:"min=a"`
+	}](DefaultFillOptions).Shrink(struct {
+		Email string `validate:"email"`
+		Bad   int    `validate:"min=a"`
+	}{"email", 1}))
+	generated := NewGenerator[TestFillStatus](FillOptions{
+		Generators: map[reflect.Type]FakeGenerator{
+			reflect.TypeOf(TestFillStatus("")): func(r *rand.Rand) interface{} { return "status" },
+		},
+	})
+	assert.Empty(t, generated.Shrink("status"))
+}
+
+func TestGenerator_Shrink_with_constraints(t *testing.T) {
+	type constrained struct {
+		Count  int         `validate:"gt=5"`
+		Ratio  float64     `validate:"gte=1.5"`
+		Word   string      `validate:"min=2"`
+		Values []int       `validate:"min=2"`
+		Labels map[int]int `validate:"len=1"`
+		Level  int8        `validate:"oneof=3 4"`
+	}
+
+	g := NewGenerator[constrained](DefaultFillOptions)
+	obj := constrained{Count: 6, Ratio: 1.5, Word: "ab", Values: []int{0, 0}, Labels: map[int]int{1: 0}, Level: 3}
+	assert.Empty(t, g.Shrink(obj))
+
+	obj.Count, obj.Word, obj.Level = 8, "abcd", 4
+	assert.Equal(t, []constrained{
+		{Count: 6, Ratio: 1.5, Word: "abcd", Values: obj.Values, Labels: obj.Labels, Level: 4},
+		{Count: 7, Ratio: 1.5, Word: "abcd", Values: obj.Values, Labels: obj.Labels, Level: 4},
+		{Count: 8, Ratio: 1.5, Word: "ab", Values: obj.Values, Labels: obj.Labels, Level: 4},
+		{Count: 8, Ratio: 1.5, Word: "abc", Values: obj.Values, Labels: obj.Labels, Level: 4},
+		{Count: 8, Ratio: 1.5, Word: "abcd", Values: obj.Values, Labels: obj.Labels, Level: 3},
+	}, g.Shrink(obj))
+}
@@ -0,0 +1,285 @@
+package reflectme
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// RedactedMask replaces the masked string values
+const RedactedMask = "****"
+
+const (
+	redactMask = "mask"
+	redactHash = "hash"
+	redactDrop = "drop"
+)
+
+type redactor struct {
+	copies map[visitedPointer]reflect.Value
+}
+
+// Redact returns a deep copy of obj in which the struct fields tagged
+// `sensitive:"true"` or `redact:"mask|hash|drop"` are redacted, walking
+// through nested structs, pointers, slices, arrays, maps and Optional
+// values. sensitive fields are masked, and the redact actions are:
+//   - mask replaces strings with RedactedMask
+//   - hash replaces strings with their "sha256:<hex>" fingerprint, so
+//     equal secrets can still be matched
+//   - drop zeroes the field
+//
+// Masked and hashed slices, arrays, maps (by value) and pointers are
+// redacted element by element, other values are zeroed and zero values
+// are kept. Unexported fields are copied as they are and can't be
+// tagged. The copy keeps the obj type and the shared and cyclic pointers,
+// maps and slices.
+func Redact(obj interface{}) (interface{}, error) {
+	if obj == nil {
+		return nil, nil
+	}
+
+	r := &redactor{copies: make(map[visitedPointer]reflect.Value)}
+	v, err := r.copy(reflect.ValueOf(obj), "")
+	if err != nil {
+		return nil, err
+	}
+
+	return v.Interface(), nil
+}
+
+// copy returns a deep copy of v with the tagged struct fields redacted
+func (r *redactor) copy(v reflect.Value, path string) (reflect.Value, error) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v, nil
+		}
+		key := visitedPointer{v.Pointer(), v.Type()}
+		if c, ok := r.copies[key]; ok {
+			return c, nil
+		}
+		c := reflect.New(v.Type().Elem())
+		r.copies[key] = c
+		elem, err := r.copy(v.Elem(), path)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		c.Elem().Set(elem)
+		return c, nil
+	case reflect.Interface:
+		if v.IsNil() {
+			return v, nil
+		}
+		elem, err := r.copy(v.Elem(), path)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		c := reflect.New(v.Type()).Elem()
+		c.Set(elem)
+		return c, nil
+	case reflect.Struct:
+		if isOptionalType(v.Type()) {
+			return copyOptional(v, func(value reflect.Value) (reflect.Value, error) {
+				return r.copy(value, path)
+			})
+		}
+		return r.copyStruct(v, path)
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return v, nil
+		}
+		c, copied := newRedactedCollection(v, r.copies)
+		if copied {
+			return c, nil
+		}
+		for i := 0; i < v.Len(); i++ {
+			elem, err := r.copy(v.Index(i), fmt.Sprintf("%s[%d]", path, i))
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			c.Index(i).Set(elem)
+		}
+		return c, nil
+	case reflect.Map:
+		if v.IsNil() {
+			return v, nil
+		}
+		c, copied := newRedactedCollection(v, r.copies)
+		if copied {
+			return c, nil
+		}
+		iter := v.MapRange()
+		for iter.Next() {
+			elem, err := r.copy(iter.Value(), fmt.Sprintf("%s[%v]", path, iter.Key()))
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			c.SetMapIndex(iter.Key(), elem)
+		}
+		return c, nil
+	}
+
+	return v, nil
+}
+
+func (r *redactor) copyStruct(v reflect.Value, path string) (reflect.Value, error) {
+	c := reflect.New(v.Type()).Elem()
+	c.Set(v)
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		fieldPath := field.Name
+		if len(path) > 0 {
+			fieldPath = path + "." + field.Name
+		}
+
+		action, err := redactAction(field, fieldPath)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if !isExportableField(field) {
+			if action != "" {
+				return reflect.Value{}, fmt.Errorf("Cannot redact non-exported field %s", fieldPath)
+			}
+			continue
+		}
+
+		var value reflect.Value
+		if action != "" {
+			value = redactValue(v.Field(i), action, make(map[visitedPointer]reflect.Value))
+		} else if value, err = r.copy(v.Field(i), fieldPath); err != nil {
+			return reflect.Value{}, err
+		}
+		c.Field(i).Set(value)
+	}
+
+	return c, nil
+}
+
+// redactAction returns the field redact action, or an empty string when
+// the field isn't redacted
+func redactAction(field reflect.StructField, path string) (string, error) {
+	if tag, ok := field.Tag.Lookup("redact"); ok {
+		action, _ := parseTagValue(tag)
+		switch action {
+		case redactMask, redactHash, redactDrop:
+			return action, nil
+		}
+		return "", fmt.Errorf("Invalid redact action: %s in field %s", action, path)
+	}
+
+	if tag, ok := field.Tag.Lookup("sensitive"); ok {
+		sensitive, err := strconv.ParseBool(tag)
+		if err != nil {
+			return "", fmt.Errorf("Invalid sensitive value: %s in field %s", tag, path)
+		}
+		if sensitive {
+			return redactMask, nil
+		}
+	}
+
+	return "", nil
+}
+
+// redactValue returns a copy of v redacted with action. copies are the
+// redacted pointers, maps and slices, so cycles are kept.
+func redactValue(v reflect.Value, action string, copies map[visitedPointer]reflect.Value) reflect.Value {
+	if action == redactDrop || v.IsZero() {
+		return reflect.Zero(v.Type())
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		s := RedactedMask
+		if action == redactHash {
+			sum := sha256.Sum256([]byte(v.String()))
+			s = "sha256:" + hex.EncodeToString(sum[:])
+		}
+		c := reflect.New(v.Type()).Elem()
+		c.SetString(s)
+		return c
+	case reflect.Ptr:
+		key := visitedPointer{v.Pointer(), v.Type()}
+		if c, ok := copies[key]; ok {
+			return c
+		}
+		c := reflect.New(v.Type().Elem())
+		copies[key] = c
+		c.Elem().Set(redactValue(v.Elem(), action, copies))
+		return c
+	case reflect.Interface:
+		c := reflect.New(v.Type()).Elem()
+		c.Set(redactValue(v.Elem(), action, copies))
+		return c
+	case reflect.Slice, reflect.Array:
+		c, copied := newRedactedCollection(v, copies)
+		if !copied {
+			for i := 0; i < v.Len(); i++ {
+				c.Index(i).Set(redactValue(v.Index(i), action, copies))
+			}
+		}
+		return c
+	case reflect.Map:
+		c, copied := newRedactedCollection(v, copies)
+		if !copied {
+			iter := v.MapRange()
+			for iter.Next() {
+				c.SetMapIndex(iter.Key(), redactValue(iter.Value(), action, copies))
+			}
+		}
+		return c
+	case reflect.Struct:
+		if isOptionalType(v.Type()) {
+			c, _ := copyOptional(v, func(value reflect.Value) (reflect.Value, error) {
+				return redactValue(value, action, copies), nil
+			})
+			return c
+		}
+	}
+
+	return reflect.Zero(v.Type())
+}
+
+// newRedactedCollection returns an empty copy of the v slice, array or map
+// to fill, registered in copies. When v was already copied, the copy is
+// returned with true.
+func newRedactedCollection(v reflect.Value, copies map[visitedPointer]reflect.Value) (reflect.Value, bool) {
+	c := reflect.New(v.Type()).Elem()
+	if v.Kind() == reflect.Array {
+		return c, false
+	}
+
+	key := visitedPointer{v.Pointer(), v.Type()}
+	// Slices sharing their first element can have different lengths, while
+	// map copies grow as they are filled
+	if copied, ok := copies[key]; ok && (v.Kind() == reflect.Map || copied.Len() == v.Len()) {
+		return copied, true
+	}
+	if v.Kind() == reflect.Slice {
+		c.Set(reflect.MakeSlice(v.Type(), v.Len(), v.Len()))
+	} else {
+		c.Set(reflect.MakeMapWithSize(v.Type(), v.Len()))
+	}
+	copies[key] = c
+
+	return c, false
+}
+
+// copyOptional returns a copy of the v Optional with its set value
+// copied by copyValue
+func copyOptional(v reflect.Value, copyValue func(reflect.Value) (reflect.Value, error)) (reflect.Value, error) {
+	value, state := v.Interface().(optional).optionalValue()
+	if state != optionalSet || value == nil {
+		return v, nil
+	}
+
+	copied, err := copyValue(reflect.ValueOf(value))
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	c := reflect.New(v.Type()).Elem()
+	err = setOptionalField(c, copied.Interface())
+
+	return c, err
+}
@@ -0,0 +1,269 @@
+package reflectme
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TestRedactCard struct {
+	Number string `redact:"mask"`
+	CVV    int    `sensitive:"true"`
+	Holder string `sensitive:"false"`
+}
+
+type TestRedactStruct struct {
+	User     string
+	Password string `sensitive:"true"`
+	Card     TestRedactCard
+	Next     *TestRedactStruct
+	internal string
+}
+
+type TestRedactInvalid struct {
+	Value string `redact:"erase"`
+}
+
+func TestRedact_on_sensitive_fields(t *testing.T) {
+	redacted, err := Redact(TestRedactCard{Number: "4111", CVV: 123, Holder: "holder"})
+	assert.NoError(t, err)
+	assert.Equal(t, TestRedactCard{Number: RedactedMask, Holder: "holder"}, redacted)
+}
+
+func TestRedact_with_actions(t *testing.T) {
+	type actions struct {
+		Masked string  `redact:"mask"`
+		Hashed string  `redact:"hash"`
+		Drop   *string `redact:"drop"`
+		Empty  string  `sensitive:"true"`
+	}
+	secret := "secret"
+
+	redacted, err := Redact(actions{Masked: "masked", Hashed: "token", Drop: &secret})
+	assert.NoError(t, err)
+	assert.Equal(t, actions{
+		Masked: RedactedMask,
+		Hashed: "sha256:3c469e9d6c5875d37a43f353d4f88e61fcf812c66eee3457465a40b0da4153e0",
+	}, redacted)
+}
+
+func TestRedact_on_masked_collections(t *testing.T) {
+	type collections struct {
+		Keys    []string          `redact:"mask"`
+		Pins    [2]string         `redact:"hash"`
+		Headers map[string]string `redact:"mask,omitempty"`
+	}
+	obj := collections{
+		Keys:    []string{"a", ""},
+		Pins:    [2]string{"1234"},
+		Headers: map[string]string{"Authorization": "Bearer token"},
+	}
+
+	redacted, err := Redact(obj)
+	assert.NoError(t, err)
+	assert.Equal(t, collections{
+		Keys:    []string{RedactedMask, ""},
+		Pins:    [2]string{"sha256:03ac674216f3e15c761ee1a5e255f067953623c8b388b4459e13f978d7c846f4", ""},
+		Headers: map[string]string{"Authorization": RedactedMask},
+	}, redacted)
+	assert.Equal(t, []string{"a", ""}, obj.Keys)
+}
+
+func TestRedact_on_masked_pointers_and_interfaces(t *testing.T) {
+	type values struct {
+		APIKey *string      `sensitive:"true"`
+		Any    interface{}  `redact:"mask"`
+		Count  int          `redact:"mask"`
+		Other  *interface{} `redact:"mask"`
+	}
+	secret := "secret"
+	obj := values{APIKey: &secret, Any: []interface{}{1, "any"}, Count: 1}
+
+	redacted, err := Redact(obj)
+	assert.NoError(t, err)
+	r := redacted.(values)
+	assert.Equal(t, RedactedMask, *r.APIKey)
+	assert.Equal(t, []interface{}{0, RedactedMask}, r.Any)
+	assert.Zero(t, r.Count)
+	assert.Nil(t, r.Other)
+	assert.Equal(t, "secret", secret)
+}
+
+func TestRedact_on_optionals(t *testing.T) {
+	type optionals struct {
+		Note  Optional[string] `redact:"mask"`
+		Empty Optional[string] `redact:"mask"`
+		Null  Optional[string] `redact:"mask"`
+		Last  Optional[TestRedactCard]
+		Any   interface{} `redact:"mask"`
+	}
+	card := TestRedactCard{Number: "4111"}
+	obj := optionals{Note: Some("note"), Null: Null[string](), Last: Some(card), Any: Some("some")}
+
+	redacted, err := Redact(obj)
+	assert.NoError(t, err)
+	assert.Equal(t, optionals{
+		Note: Some(RedactedMask),
+		Null: Null[string](),
+		Last: Some(TestRedactCard{Number: RedactedMask}),
+		Any:  Some(RedactedMask),
+	}, redacted)
+	assert.Equal(t, Some(card), obj.Last)
+}
+
+func TestRedact_on_nested_structs(t *testing.T) {
+	type nested struct {
+		Card     TestRedactCard
+		Cards    []TestRedactCard
+		Wallet   map[string]*TestRedactCard
+		Previous [1]TestRedactCard
+		Other    interface{}
+	}
+	card := TestRedactCard{Number: "4111", Holder: "holder"}
+	obj := nested{
+		Card:     card,
+		Cards:    []TestRedactCard{card},
+		Wallet:   map[string]*TestRedactCard{"main": &card},
+		Previous: [1]TestRedactCard{card},
+		Other:    card,
+	}
+
+	redacted, err := Redact(obj)
+	assert.NoError(t, err)
+	redactedCard := TestRedactCard{Number: RedactedMask, Holder: "holder"}
+	assert.Equal(t, nested{
+		Card:     redactedCard,
+		Cards:    []TestRedactCard{redactedCard},
+		Wallet:   map[string]*TestRedactCard{"main": &redactedCard},
+		Previous: [1]TestRedactCard{redactedCard},
+		Other:    redactedCard,
+	}, redacted)
+	assert.Equal(t, "4111", obj.Wallet["main"].Number)
+}
+
+func TestRedact_on_unexported_fields(t *testing.T) {
+	redacted, err := Redact(TestRedactStruct{Password: "password", internal: "internal"})
+	assert.NoError(t, err)
+	assert.Equal(t, TestRedactStruct{Password: RedactedMask, internal: "internal"}, redacted)
+}
+
+func TestRedact_on_pointer_cycles(t *testing.T) {
+	obj := &TestRedactStruct{Password: "password"}
+	obj.Next = obj
+
+	redacted, err := Redact(obj)
+	assert.NoError(t, err)
+	r := redacted.(*TestRedactStruct)
+	assert.Equal(t, RedactedMask, r.Password)
+	assert.Same(t, r, r.Next)
+	assert.Equal(t, "password", obj.Password)
+}
+
+func TestRedact_on_map_cycles(t *testing.T) {
+	m := map[string]interface{}{"card": TestRedactCard{CVV: 123}}
+	m["self"] = m
+
+	redacted, err := Redact(m)
+	assert.NoError(t, err)
+	r := redacted.(map[string]interface{})
+	assert.Equal(t, TestRedactCard{}, r["card"])
+	r["self"].(map[string]interface{})["new"] = 1
+	assert.Equal(t, 1, r["new"])
+	assert.NotContains(t, m, "new")
+}
+
+func TestRedact_on_slice_cycles(t *testing.T) {
+	s := []interface{}{TestRedactCard{CVV: 123}, nil}
+	s[1] = s
+
+	redacted, err := Redact(s)
+	assert.NoError(t, err)
+	r := redacted.([]interface{})
+	assert.Equal(t, TestRedactCard{}, r[0])
+	assert.Equal(t, TestRedactCard{}, r[1].([]interface{})[0])
+	assert.Equal(t, TestRedactCard{}, r[1].([]interface{})[1].([]interface{})[0])
+
+	// Slices sharing their first element aren't the same
+	s = []interface{}{nil, "b"}
+	s[0] = s[:1]
+	redacted, err = Redact(s)
+	assert.NoError(t, err)
+	assert.Len(t, redacted.([]interface{})[0], 1)
+}
+
+func TestRedact_on_masked_cycles(t *testing.T) {
+	type cycles struct {
+		Map     interface{} `redact:"mask"`
+		Slice   interface{} `redact:"mask"`
+		Pointer interface{} `redact:"mask"`
+	}
+	m := map[string]interface{}{"token": "token"}
+	m["self"] = m
+	s := []interface{}{"token", nil}
+	s[1] = s
+	var p interface{}
+	p = &p
+
+	redacted, err := Redact(cycles{m, s, p})
+	assert.NoError(t, err)
+	r := redacted.(cycles)
+	redactedMap := r.Map.(map[string]interface{})
+	assert.Equal(t, RedactedMask, redactedMap["token"])
+	assert.Equal(t, RedactedMask, redactedMap["self"].(map[string]interface{})["token"])
+	assert.Equal(t, RedactedMask, r.Slice.([]interface{})[1].([]interface{})[0])
+	redactedPointer := r.Pointer.(*interface{})
+	assert.Same(t, redactedPointer, *redactedPointer)
+	assert.Equal(t, "token", m["token"])
+}
+
+func TestRedact_on_values(t *testing.T) {
+	redacted, err := Redact(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, redacted)
+
+	redacted, err = Redact([]TestRedactCard(nil))
+	assert.NoError(t, err)
+	assert.Equal(t, []TestRedactCard(nil), redacted)
+
+	redacted, err = Redact(map[string]interface{}{"card": TestRedactCard{CVV: 123}, "nil": nil, "map": map[string]int(nil)})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"card": TestRedactCard{}, "nil": nil, "map": map[string]int(nil)}, redacted)
+
+	redacted, err = Redact(Null[TestRedactCard]())
+	assert.NoError(t, err)
+	assert.Equal(t, Null[TestRedactCard](), redacted)
+
+	redacted, err = Redact((*TestRedactCard)(nil))
+	assert.NoError(t, err)
+	assert.Equal(t, (*TestRedactCard)(nil), redacted)
+}
+
+func TestRedact_on_errors(t *testing.T) {
+	invalid := TestRedactInvalid{Value: "value"}
+	cases := []struct {
+		obj  interface{}
+		path string
+	}{
+		{invalid, "Value"},
+		{&invalid, "Value"},
+		{[]interface{}{invalid}, "[0].Value"},
+		{[1]TestRedactInvalid{invalid}, "[0].Value"},
+		{map[string]TestRedactInvalid{"key": invalid}, "[key].Value"},
+		{struct{ Optional Optional[TestRedactInvalid] }{Some(invalid)}, "Optional.Value"},
+		{struct{ Nested *TestRedactInvalid }{&invalid}, "Nested.Value"},
+	}
+	for _, c := range cases {
+		_, err := Redact(c.obj)
+		assert.EqualError(t, err, "Invalid redact action: erase in field "+c.path)
+	}
+
+	_, err := Redact(struct {
+		Secret bool `sensitive:"yes"`
+	}{})
+	assert.EqualError(t, err, "Invalid sensitive value: yes in field Secret")
+
+	_, err = Redact(struct {
+		secret string `sensitive:"true"`
+	}{})
+	assert.EqualError(t, err, "Cannot redact non-exported field secret")
+}
@@ -15,6 +15,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 )
 
@@ -23,6 +24,42 @@ type (
 	CopyOptions struct {
 		CopyZeroValues       bool
 		IgnoreNotFoundFields bool
+		// FlattenMaps writes dotted keys to map destinations
+		// instead of nested maps
+		FlattenMaps bool
+		// Include copies only the matching paths when not empty. A path
+		// matches its nested paths too and "*" matches any path segment,
+		// eg "Address" or "*.Name". Slices and maps are copied as a whole,
+		// so paths to their elements, like "Items.*.Name", are invalid.
+		Include []string
+		// Exclude skips the matching paths, with the same rules as Include
+		Exclude []string
+
+		report *CopyReport
+	}
+
+	// TraverseOptions are options for the functions that walk
+	// through nested struct fields
+	TraverseOptions struct {
+		// MaxDepth limits how many levels of fields are walked.
+		// Top level fields are at depth 1. Zero means no limit.
+		MaxDepth int
+		// ExpandNilPointers walks the fields of nil pointer to struct
+		// fields using only their type. Recursive types are expanded
+		// once per path.
+		ExpandNilPointers bool
+		// WalkCollections walks the fields of the struct elements of
+		// slices, arrays and maps. Elements paths are like "Items[0].Name"
+		// and "Labels[key].Name", map keys sorted by their string form.
+		WalkCollections bool
+	}
+
+	// PathOptions are options for resolving field paths
+	PathOptions struct {
+		// AllowMethodCalls allows path segments ending with "()" that
+		// call a method with no args, eg "Customer.FullName()" or
+		// "Order.Total().Amount". Disable it for untrusted paths.
+		AllowMethodCalls bool
 	}
 )
 
@@ -32,17 +69,34 @@ var (
 		CopyZeroValues:       true,
 		IgnoreNotFoundFields: true,
 	}
+
+	// DefaultTraverseOptions are the default options for the functions
+	// that walk through nested struct fields
+	DefaultTraverseOptions = TraverseOptions{}
+
+	// DefaultPathOptions are the default options for resolving field paths
+	DefaultPathOptions = PathOptions{
+		AllowMethodCalls: true,
+	}
 )
 
 // GetField returns the value of the provided obj field. obj can whether
 // be a structure or pointer to structure.
 func GetField(obj interface{}, name string) (interface{}, error) {
-	field, err := getInnerField(obj, name)
+	return GetFieldWithOptions(obj, name, DefaultPathOptions)
+}
+
+// GetFieldWithOptions returns the value of the provided obj field with
+// PathOptions. obj can whether be a structure or pointer to structure.
+// Optional fields return their set value, or nil when absent or null.
+func GetFieldWithOptions(obj interface{}, name string, options PathOptions) (interface{}, error) {
+	field, err := getInnerFieldValueOrType(obj, name, name, true, options)
 	if err != nil {
 		return nil, err
 	}
 
-	return field.Interface(), nil
+	value, _ := unwrapOptional(field.(reflect.Value).Interface())
+	return value, nil
 }
 
 // GetFieldKind returns the kind of the provided obj field. obj can whether
@@ -75,15 +129,33 @@ func GetFieldTag(obj interface{}, fieldName, tagKey string) (string, error) {
 // to be a pointer to a struct, otherwise it will soundly fail. Provided
 // value type should match with the struct field you're trying to set.
 func SetField(s interface{}, name string, value interface{}) error {
-	return setField(reflect.ValueOf(s), name, name, value)
+	v := reflect.ValueOf(s)
+	// The path is resolved first, so an invalid one fails before walking
+	// through the target
+	if v.Kind() == reflect.Ptr {
+		if _, err := resolveTypePath(v.Type(), name, PathOptions{}); err != nil || strings.Contains(name, "[") {
+			return fmt.Errorf("No such field: %s in obj", name)
+		}
+	}
+
+	return setField(v, name, name, value)
 }
 
-// CopyField copies the value from/to with field name
+// CopyField copies the value from/to with field name. Absent Optional
+// values aren't copied.
 func CopyField(from interface{}, to interface{}, name string) error {
-	value, err := GetField(from, name)
+	field, err := getInnerField(from, name)
 	if err != nil {
 		return err
 	}
+
+	if !field.CanInterface() {
+		return errors.New("Cannot CopyField on a non-exported struct field")
+	}
+	value := field.Interface()
+	if _, present := unwrapOptional(value); !present {
+		return nil
+	}
 	return SetField(to, name, value)
 }
 
@@ -93,6 +165,20 @@ func setField(v reflect.Value, name string, currName string, value interface{})
 		return errors.New("Not a pointer value")
 	}
 	v = reflect.Indirect(v)
+	if len(currName) == 0 {
+		if isOptionalType(v.Type()) {
+			return setOptionalField(v, value)
+		}
+		// Optional values are unwrapped into plain fields
+		var present bool
+		if value, present = unwrapOptional(value); !present {
+			return nil
+		}
+		if value == nil {
+			v.Set(reflect.Zero(v.Type()))
+			return nil
+		}
+	}
 	switch v.Kind() {
 	case reflect.Struct, reflect.Ptr:
 		if len(currName) == 0 {
@@ -104,20 +190,34 @@ func setField(v reflect.Value, name string, currName string, value interface{})
 			return nil
 		}
 
-		currName, nextFieldName := getCurrAndNextFieldName(currName)
-		if v.Kind() == reflect.Struct {
-			v = v.FieldByName(currName)
-		} else {
-			v = v.Elem().FieldByName(currName)
-		}
-		if !v.IsValid() {
-			return fmt.Errorf("No such field: %s in obj", name)
+		if v.Kind() == reflect.Ptr {
+			if v.Type().Elem().Kind() != reflect.Struct {
+				return fmt.Errorf("No such field: %s in obj", name)
+			}
+			// Nil pointers in the middle of the path are allocated, but
+			// only set once the value is, so a failed SetField doesn't
+			// change the target
+			if v.IsNil() {
+				allocated := reflect.New(v.Type().Elem())
+				if err := setField(allocated, name, currName, value); err != nil {
+					return err
+				}
+				v.Set(allocated)
+				return nil
+			}
+			v = v.Elem()
 		}
+		currName, nextFieldName := getCurrAndNextFieldName(currName)
+		// SetField resolved the path, so the field exists
+		v = v.FieldByName(currName)
 		err := setField(v.Addr(), name, nextFieldName, value)
 		if err != nil {
 			return err
 		}
 	default:
+		if len(currName) > 0 {
+			return fmt.Errorf("No such field: %s in obj", name)
+		}
 		valueOf := reflect.ValueOf(value)
 		if v.Type() != valueOf.Type() {
 			return fmt.Errorf("Provided value type (%v) didn't match obj field type (%v)\n", valueOf.Type(), v.Type())
@@ -149,36 +249,20 @@ func HasField(obj interface{}, name string) (bool, error) {
 // FieldsNames returns the struct fields names list. obj can whether
 // be a structure or pointer to structure.
 func FieldsNames(obj interface{}) ([]string, error) {
-	return fieldsNames(obj, "")
+	return FieldsNamesWithOptions(obj, DefaultTraverseOptions)
 }
 
-func fieldsNames(obj interface{}, parent string) ([]string, error) {
-	if !hasValidType(obj, []reflect.Kind{reflect.Struct, reflect.Ptr}) {
-		return nil, errors.New("Cannot use GetField on a non-struct interface")
-	}
-
-	objValue := reflectValue(obj)
-	objType := objValue.Type()
-	fieldsCount := objType.NumField()
-
+// FieldsNamesWithOptions returns the struct fields names list with
+// TraverseOptions. obj can whether be a structure or pointer to structure.
+// Pointers already visited in the current path are not walked again and
+// a nil pointer to structure lists the fields of its type.
+func FieldsNamesWithOptions(obj interface{}, options TraverseOptions) ([]string, error) {
 	var fields []string
-	for i := 0; i < fieldsCount; i++ {
-		field := objType.Field(i)
-		if !isExportableField(field) {
-			continue
-		}
-
-		var fieldName string
-		fieldName = field.Name
-		if len(parent) > 0 {
-			fieldName = parent + "." + fieldName
-		}
-		fields = append(fields, fieldName)
-
-		if k := objValue.Field(i).Kind(); k == reflect.Struct || k == reflect.Ptr {
-			nestedFields, _ := fieldsNames(objValue.Field(i).Interface(), fieldName)
-			fields = append(fields, nestedFields...)
-		}
+	err := traverse(obj, options, func(path string, field reflect.StructField, value reflect.Value) {
+		fields = append(fields, path)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return fields, nil
@@ -187,31 +271,42 @@ func fieldsNames(obj interface{}, parent string) ([]string, error) {
 // Fields returns the struct fields list. obj can whether
 // be a structure or pointer to structure.
 func Fields(obj interface{}) ([]reflect.StructField, error) {
-	if !hasValidType(obj, []reflect.Kind{reflect.Struct, reflect.Ptr}) {
-		return nil, errors.New("Cannot use GetField on a non-struct interface")
-	}
-
-	objValue := reflectValue(obj)
-	objType := objValue.Type()
-	fieldsCount := objType.NumField()
+	return StructFieldsWithOptions(obj, DefaultTraverseOptions)
+}
 
+// StructFieldsWithOptions returns the struct fields list with
+// TraverseOptions. obj can whether be a structure or pointer to structure.
+// Pointers already visited in the current path are not walked again.
+func StructFieldsWithOptions(obj interface{}, options TraverseOptions) ([]reflect.StructField, error) {
 	var fields []reflect.StructField
-	for i := 0; i < fieldsCount; i++ {
-		field := objType.Field(i)
-		if !isExportableField(field) {
-			continue
-		}
-
+	err := traverse(obj, options, func(path string, field reflect.StructField, value reflect.Value) {
 		fields = append(fields, field)
-		if k := objValue.Field(i).Kind(); k == reflect.Struct || k == reflect.Ptr {
-			nestedFields, _ := Fields(objValue.Field(i).Interface())
-			fields = append(fields, nestedFields...)
-		}
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return fields, nil
 }
 
+// TypeFieldsNames returns the fields names list of a struct type
+// without needing an instance of it. Nil pointers are always expanded
+// and recursive types are walked once per path.
+func TypeFieldsNames(t reflect.Type, options TraverseOptions) ([]string, error) {
+	if t == nil {
+		return nil, errors.New("Cannot use TypeFieldsNames on a nil type")
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, errors.New("Cannot use TypeFieldsNames on a non-struct type")
+	}
+
+	options.ExpandNilPointers = true
+	return FieldsNamesWithOptions(reflect.Zero(reflect.PtrTo(t)).Interface(), options)
+}
+
 // Items returns the field - value struct pairs as a map. obj can whether
 // be a structure or pointer to structure.
 func Items(obj interface{}) (map[string]interface{}, error) {
@@ -239,6 +334,26 @@ func Items(obj interface{}) (map[string]interface{}, error) {
 	return items, nil
 }
 
+// ItemsWithOptions returns the field - value struct pairs as a map keyed
+// by the dotted field name, walking through nested structs with
+// TraverseOptions. obj can whether be a structure or pointer to structure.
+// Fields walked only by type have nil values.
+func ItemsWithOptions(obj interface{}, options TraverseOptions) (map[string]interface{}, error) {
+	items := make(map[string]interface{})
+	err := traverse(obj, options, func(path string, field reflect.StructField, value reflect.Value) {
+		if value.IsValid() {
+			items[path] = value.Interface()
+		} else {
+			items[path] = nil
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
 // Tags lists the struct tag fields. obj can whether
 // be a structure or pointer to structure.
 func Tags(obj interface{}, key string) (map[string]string, error) {
@@ -263,6 +378,21 @@ func Tags(obj interface{}, key string) (map[string]string, error) {
 	return tags, nil
 }
 
+// TagsWithOptions lists the struct tag fields keyed by the dotted field
+// name, walking through nested structs with TraverseOptions. obj can
+// whether be a structure or pointer to structure.
+func TagsWithOptions(obj interface{}, key string, options TraverseOptions) (map[string]string, error) {
+	tags := make(map[string]string)
+	err := traverse(obj, options, func(path string, field reflect.StructField, value reflect.Value) {
+		tags[path] = field.Tag.Get(key)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
 // Copy copies all values from "from" to "to" with
 // DefaultCopyOptions
 func Copy(from interface{}, to interface{}) error {
@@ -270,30 +400,71 @@ func Copy(from interface{}, to interface{}) error {
 }
 
 // CopyWithOptions copies all values from "from" to "to" with
-// CopyOptions
+// CopyOptions. "from" can also be a map with string keys, either dotted
+// field names or nested maps, and "to" can also be a *map[string]interface{}.
+// Maps copied into maps don't share their nested maps and slices.
+// Slices and maps, like []A into *[]B or map[K]A into *map[K]B, are copied
+// element by element, including the ones in struct fields.
 func CopyWithOptions(from interface{}, to interface{}, options CopyOptions) error {
 	if !isPointer(to) {
 		return errors.New("To must be a pointer")
 	}
+	if isCollectionPointer(to) {
+		return copyCollection(from, to, options)
+	}
+	if v := reflect.ValueOf(from); !v.IsValid() || (v.Kind() == reflect.Ptr && v.IsNil()) {
+		return fmt.Errorf("Cannot copy nil into %v", reflect.TypeOf(to).Elem())
+	}
+	if t := reflect.TypeOf(from); t != nil && structType(t) != nil {
+		if err := checkSelectedPaths(t, options.Include, options.Exclude); err != nil {
+			return err
+		}
+	}
+	if isStringMap(from) {
+		return copyFromMap(from, to, options)
+	}
+	if isMapPointer(to) {
+		return copyToMap(from, to, options)
+	}
 	// Already check if is a pointer so should never get an error
 	fromFields, _ := FieldsNames(from)
-	for _, field := range fromFields {
-		// Reflecting using FieldsNames so should never get an error
-		v, _ := GetField(from, field)
-		if !options.CopyZeroValues && IsZeroValue(v) {
+	for i, field := range fromFields {
+		hasNested := i+1 < len(fromFields) && isNestedPath(field, fromFields[i+1])
+		if !options.selects(field, hasNested) {
 			continue
 		}
-		err := SetField(to, field, v)
-		if !options.IgnoreNotFoundFields && err != nil {
-			return err
+		// Reflecting using FieldsNames so should never get an error. Optional
+		// values are kept wrapped, so absent ones are skipped and null ones
+		// are copied.
+		fieldValue, _ := getInnerField(from, field)
+		v := fieldValue.Interface()
+		if _, present := unwrapOptional(v); !present || (!options.CopyZeroValues && IsZeroValue(v)) {
+			options.report.skippedZero(field)
+			continue
+		}
+		err := copyField(to, field, v, options)
+		if err != nil && copiesNestedPaths(reflect.TypeOf(to), field, hasNested) {
+			continue
+		}
+		if err != nil {
+			options.report.failed(to, field)
+			if !options.IgnoreNotFoundFields {
+				return err
+			}
+			continue
 		}
+		options.report.copied(field)
 	}
 	return nil
 }
 
 // IsZeroValue indicates if the interface has value
 // according to golang spec: https://golang.org/ref/spec#The_zero_value
+// Optional values are zero only when absent, as null is a value.
 func IsZeroValue(i interface{}) bool {
+	if _, present := unwrapOptional(i); !present {
+		return true
+	}
 	return i == nil || reflect.DeepEqual(i, reflect.Zero(reflect.TypeOf(i)).Interface())
 }
 
@@ -320,6 +491,9 @@ func isExportableField(field reflect.StructField) bool {
 }
 
 func hasValidType(obj interface{}, types []reflect.Kind) bool {
+	if obj == nil {
+		return false
+	}
 	for _, t := range types {
 		if reflect.TypeOf(obj).Kind() == t {
 			return true
@@ -336,59 +510,290 @@ func isPointer(obj interface{}) bool {
 func getCurrAndNextFieldName(name string) (string, string) {
 	currName := name
 	nextFieldName := ""
-	if i := strings.Index(name, "."); i > -1 {
-		currName = name[0:i]
-		nextFieldName = name[i+1 : len(name)]
+	// Dots inside index segments, eg "Map[a.b]", are part of the key
+	depth := 0
+	for i, r := range name {
+		switch {
+		case r == '[':
+			depth++
+		case r == ']' && depth > 0:
+			depth--
+		case r == '.' && depth == 0:
+			return name[0:i], name[i+1:]
+		}
 	}
 	return currName, nextFieldName
 }
 
 func getInnerField(obj interface{}, name string) (reflect.Value, error) {
-	v, err := getInnerFieldValueOrType(obj, name, name, true)
+	v, err := getInnerFieldValueOrType(obj, name, name, true, DefaultPathOptions)
 	return v.(reflect.Value), err
 }
 
 func getInnerFieldType(obj interface{}, name string) (reflect.StructField, error) {
-	v, err := getInnerFieldValueOrType(obj, name, name, false)
+	v, err := getInnerFieldValueOrType(obj, name, name, false, DefaultPathOptions)
 	return v.(reflect.StructField), err
 }
 
-func getInnerFieldValueOrType(obj interface{}, fullName, name string, value bool) (interface{}, error) {
+func getInnerFieldValueOrType(obj interface{}, fullName, name string, value bool, options PathOptions) (interface{}, error) {
 	var zeroValue interface{}
 	if value {
 		zeroValue = reflect.Value{}
 	} else {
 		zeroValue = reflect.StructField{}
 	}
-	if !hasValidType(obj, []reflect.Kind{reflect.Struct, reflect.Ptr}) {
-		return zeroValue, fmt.Errorf("Cannot use GetField on a non-struct interface")
+
+	currName, nextFieldName := getCurrAndNextFieldName(name)
+	currName, indexes, err := splitPathIndexes(currName)
+	if err != nil {
+		return zeroValue, err
 	}
+	isLast := len(nextFieldName) == 0 && len(indexes) == 0
 
-	objValue := reflectValue(obj)
-	if i := strings.Index(name, "."); i > -1 {
-		currFieldName := name[0:i]
-		field := objValue.FieldByName(currFieldName)
+	var field reflect.Value
+	if options.AllowMethodCalls && isMethodCall(currName) {
+		if isLast && !value {
+			return zeroValue, fmt.Errorf("Not a field: %s in obj", fullName)
+		}
+		field, err = callPathMethod(obj, fullName, currName)
+		if err != nil {
+			return zeroValue, err
+		}
+	} else {
+		if !hasValidType(obj, []reflect.Kind{reflect.Struct, reflect.Ptr}) {
+			return zeroValue, fmt.Errorf("Cannot use GetField on a non-struct interface")
+		}
+
+		objValue := reflectValue(obj)
+		if !objValue.IsValid() {
+			return zeroValue, fmt.Errorf("Nil pointer: %s in obj", fullName)
+		}
+		if isLast && !value {
+			objType := objValue.Type()
+			field, ok := objType.FieldByName(name)
+			if !ok {
+				return field, fmt.Errorf("No such field: %s in obj", name)
+			}
+			return field, nil
+		}
+		field = objValue.FieldByName(currName)
 		if !field.IsValid() {
 			return zeroValue, fmt.Errorf("No such field: %s in obj", name)
 		}
-		nextFieldName := name[i+1 : len(name)]
-		return getInnerFieldValueOrType(field.Interface(), fullName, nextFieldName, value)
 	}
-	if !objValue.IsValid() {
-		return zeroValue, fmt.Errorf("Nil pointer: %s in obj", fullName)
-	}
-	if value {
-		field := objValue.FieldByName(name)
-		if !field.IsValid() {
-			return field, fmt.Errorf("No such field: %s in obj", name)
+
+	if len(indexes) > 0 {
+		if len(nextFieldName) == 0 && !value {
+			return zeroValue, fmt.Errorf("Not a field: %s in obj", fullName)
 		}
-		return field, nil
-	} else {
-		objType := objValue.Type()
-		field, ok := objType.FieldByName(name)
-		if !ok {
-			return field, fmt.Errorf("No such field: %s in obj", name)
+		field, err = indexPathValue(field, indexes, fullName)
+		if err != nil {
+			return zeroValue, err
 		}
+	}
+	if len(nextFieldName) == 0 {
 		return field, nil
 	}
+
+	return getInnerFieldValueOrType(field.Interface(), fullName, nextFieldName, value, options)
+}
+
+func isMethodCall(name string) bool {
+	return strings.HasSuffix(name, "()")
+}
+
+// callPathMethod calls the method of a "Name()" path segment. The method
+// must have no args and return a value and optionally an error.
+func callPathMethod(obj interface{}, fullName, name string) (reflect.Value, error) {
+	if obj == nil {
+		return reflect.Value{}, fmt.Errorf("Nil value: %s in obj", fullName)
+	}
+	v := reflect.ValueOf(obj)
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		return reflect.Value{}, fmt.Errorf("Nil pointer: %s in obj", fullName)
+	}
+
+	methodName := strings.TrimSuffix(name, "()")
+	method, err := methodByName(v, methodName)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if err := checkPathMethod(methodName, method.Type()); err != nil {
+		return reflect.Value{}, err
+	}
+
+	out := method.Call(nil)
+	if len(out) == 2 && !out[1].IsNil() {
+		return reflect.Value{}, out[1].Interface().(error)
+	}
+
+	return out[0], nil
+}
+
+func checkPathMethod(methodName string, methodType reflect.Type) error {
+	numOut := methodType.NumOut()
+	if methodType.NumIn() != 0 || numOut == 0 || numOut > 2 || (numOut == 2 && methodType.Out(1) != errorType) {
+		return fmt.Errorf("Method %s must have no args and return a value and an optional error", methodName)
+	}
+
+	return nil
+}
+
+type (
+	fieldVisitor func(path string, field reflect.StructField, value reflect.Value)
+
+	visitedPointer struct {
+		ptr uintptr
+		typ reflect.Type
+	}
+
+	// visitedValue is a visited pointer, map or slice, keyed by the slice
+	// length too since a slice and its prefix share the same pointer
+	visitedValue struct {
+		visitedPointer
+		len int
+	}
+
+	walker struct {
+		options    TraverseOptions
+		visit      fieldVisitor
+		unexported bool
+		pointers   map[visitedPointer]bool
+		types      map[reflect.Type]bool
+	}
+)
+
+func newVisitedValue(v reflect.Value) visitedValue {
+	visit := visitedValue{visitedPointer: visitedPointer{v.Pointer(), v.Type()}}
+	if v.Kind() == reflect.Slice {
+		visit.len = v.Len()
+	}
+
+	return visit
+}
+
+func newWalker(options TraverseOptions, visit fieldVisitor) *walker {
+	return &walker{
+		options:  options,
+		visit:    visit,
+		pointers: make(map[visitedPointer]bool),
+		types:    make(map[reflect.Type]bool),
+	}
+}
+
+// traverse calls visit for every exported field of obj, walking
+// through nested structs and pointers to structs.
+func traverse(obj interface{}, options TraverseOptions, visit fieldVisitor) error {
+	return newWalker(options, visit).traverse(obj)
+}
+
+func (w *walker) traverse(obj interface{}) error {
+	if !hasValidType(obj, []reflect.Kind{reflect.Struct, reflect.Ptr}) {
+		return errors.New("Cannot use GetField on a non-struct interface")
+	}
+
+	objType := reflect.TypeOf(obj)
+	objValue := reflect.ValueOf(obj)
+	if objType.Kind() == reflect.Ptr {
+		objType = objType.Elem()
+		if objType.Kind() != reflect.Struct {
+			return errors.New("Cannot use GetField on a non-struct interface")
+		}
+		if objValue.IsNil() {
+			objValue = reflect.Value{}
+		} else {
+			w.pointers[visitedPointer{objValue.Pointer(), objType}] = true
+			objValue = objValue.Elem()
+		}
+	}
+	w.walk(objValue, objType, "", 1)
+
+	return nil
+}
+
+// walk visits the fields of the struct type t. v is the struct value and
+// is invalid when the fields are walked only by type.
+func (w *walker) walk(v reflect.Value, t reflect.Type, parent string, depth int) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !isExportableField(field) && !w.unexported {
+			continue
+		}
+
+		fieldName := field.Name
+		if len(parent) > 0 {
+			fieldName = parent + "." + fieldName
+		}
+		var fieldValue reflect.Value
+		if v.IsValid() {
+			fieldValue = v.Field(i)
+		}
+		w.visit(fieldName, field, fieldValue)
+
+		if w.options.MaxDepth > 0 && depth >= w.options.MaxDepth {
+			continue
+		}
+		w.walkNested(fieldValue, field.Type, fieldName, depth+1)
+	}
+}
+
+func (w *walker) walkNested(v reflect.Value, t reflect.Type, path string, depth int) {
+	switch t.Kind() {
+	case reflect.Struct:
+		w.walk(v, t, path, depth)
+	case reflect.Ptr:
+		elemType := t.Elem()
+		if elemType.Kind() != reflect.Struct {
+			return
+		}
+		if v.IsValid() && !v.IsNil() {
+			key := visitedPointer{v.Pointer(), elemType}
+			if w.pointers[key] {
+				return
+			}
+			w.pointers[key] = true
+			defer delete(w.pointers, key)
+			w.walk(v.Elem(), elemType, path, depth)
+			return
+		}
+		if !w.options.ExpandNilPointers || w.types[elemType] {
+			return
+		}
+		w.types[elemType] = true
+		defer delete(w.types, elemType)
+		w.walk(reflect.Value{}, elemType, path, depth)
+	case reflect.Slice, reflect.Array:
+		if !w.walksElements(v, t) {
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			w.walkNested(v.Index(i), t.Elem(), fmt.Sprintf("%s[%d]", path, i), depth)
+		}
+	case reflect.Map:
+		if !w.walksElements(v, t) {
+			return
+		}
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+		})
+		for _, key := range keys {
+			w.walkNested(v.MapIndex(key), t.Elem(), fmt.Sprintf("%s[%v]", path, key), depth)
+		}
+	}
+}
+
+// walksElements indicates if the elements of the v collection
+// may have fields to walk
+func (w *walker) walksElements(v reflect.Value, t reflect.Type) bool {
+	if !w.options.WalkCollections || !v.IsValid() {
+		return false
+	}
+
+	switch t.Elem().Kind() {
+	case reflect.Struct, reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		return true
+	}
+
+	return false
 }
@@ -5,6 +5,7 @@
 package reflectme
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 	"time"
@@ -57,6 +58,57 @@ type NestedStruct struct {
 	Yummy int    `test:"yummytag"`
 }
 
+type TestPointerFieldsStruct struct {
+	Dummy *string
+	Yummy *int
+}
+
+type TestMethodPathStruct struct {
+	First  string
+	Last   string
+	Nested *NestedStruct
+}
+
+func (s TestMethodPathStruct) FullName() string {
+	return s.First + " " + s.Last
+}
+
+func (s *TestMethodPathStruct) NestedValue() NestedStruct {
+	return NestedStruct{Dummy: s.First}
+}
+
+func (s TestMethodPathStruct) NestedPointer() *NestedStruct {
+	return s.Nested
+}
+
+func (s TestMethodPathStruct) Any() interface{} {
+	return nil
+}
+
+func (s TestMethodPathStruct) Fail() (string, error) {
+	return "", errors.New("fail")
+}
+
+func (s TestMethodPathStruct) WithArg(arg string) string {
+	return arg
+}
+
+type TestCollectionsStruct struct {
+	Dummy    string                   `test:"dummytag"`
+	Slice    []NestedStruct           `test:"slicetag"`
+	Pointers []*NestedStruct          `test:"pointerstag"`
+	Array    [1]NestedStruct          `test:"arraytag"`
+	Map      map[string]NestedStruct  `test:"maptag"`
+	Matrix   [][]NestedStruct         `test:"matrixtag"`
+	Strings  []string                 `test:"stringstag"`
+	Nested   *TestNestedPointerStruct `test:"nestedtag"`
+}
+
+type TestRecursiveStruct struct {
+	Dummy string
+	Next  *TestRecursiveStruct
+}
+
 func TestGetField_on_struct(t *testing.T) {
 	dummyStruct := TestStruct{
 		Dummy: "test",
@@ -182,6 +234,77 @@ func TestGetField_unexported_field(t *testing.T) {
 	})
 }
 
+func TestGetField_on_nil_interface(t *testing.T) {
+	_, err := GetField(nil, "Dummy")
+	assert.Error(t, err)
+}
+
+func TestGetField_on_nested_nil_pointer_struct(t *testing.T) {
+	dummyStruct := TestInnerStruct{}
+
+	_, err := GetField(&TestMethodPathStruct{}, "Nested.Dummy")
+	assert.Error(t, err)
+	_, err = GetField(dummyStruct, "Nested.Nested.Dummy")
+	assert.NoError(t, err)
+}
+
+func TestGetField_with_method_calls(t *testing.T) {
+	dummyStruct := TestMethodPathStruct{
+		First:  "John",
+		Last:   "Doe",
+		Nested: &NestedStruct{Yummy: 1},
+	}
+
+	value, err := GetField(dummyStruct, "FullName()")
+	assert.NoError(t, err)
+	assert.Equal(t, "John Doe", value)
+
+	value, err = GetField(&dummyStruct, "NestedValue().Dummy")
+	assert.NoError(t, err)
+	assert.Equal(t, "John", value)
+
+	value, err = GetField(dummyStruct, "NestedPointer().Yummy")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, value)
+
+	value, err = GetField(TestNestedStruct{Nested: NestedStruct{Dummy: "nested"}}, "Nested.Dummy")
+	assert.NoError(t, err)
+	assert.Equal(t, "nested", value)
+}
+
+func TestGetField_with_invalid_method_calls(t *testing.T) {
+	dummyStruct := TestMethodPathStruct{}
+
+	_, err := GetField(dummyStruct, "Fail()")
+	assert.EqualError(t, err, "fail")
+
+	_, err = GetField(dummyStruct, "WithArg()")
+	assert.Error(t, err)
+
+	_, err = GetField(dummyStruct, "Obladioblada()")
+	assert.Error(t, err)
+
+	_, err = GetField(dummyStruct, "NestedPointer().Yummy")
+	assert.Error(t, err)
+
+	_, err = GetField(dummyStruct, "NestedPointer().Yummy().Dummy")
+	assert.Error(t, err)
+
+	_, err = GetField(dummyStruct, "Any().Dummy()")
+	assert.Error(t, err)
+}
+
+func TestGetFieldWithOptions_without_method_calls(t *testing.T) {
+	dummyStruct := TestMethodPathStruct{First: "John"}
+
+	_, err := GetFieldWithOptions(dummyStruct, "FullName()", PathOptions{})
+	assert.Error(t, err)
+
+	value, err := GetFieldWithOptions(dummyStruct, "First", PathOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "John", value)
+}
+
 func TestGetFieldKind_on_struct(t *testing.T) {
 	dummyStruct := TestStruct{
 		Dummy: "test",
@@ -426,6 +549,17 @@ func TestGetFieldTag_unexported_field(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestGetFieldTag_with_method_calls(t *testing.T) {
+	dummyStruct := &TestMethodPathStruct{}
+
+	tag, err := GetFieldTag(dummyStruct, "NestedValue().Dummy", "test")
+	assert.NoError(t, err)
+	assert.Equal(t, "dummytag", tag)
+
+	_, err = GetFieldTag(dummyStruct, "FullName()", "test")
+	assert.Error(t, err)
+}
+
 func TestSetField_on_struct_with_valid_value_type(t *testing.T) {
 	dummyStruct := TestStruct{
 		Dummy: "test",
@@ -470,6 +604,53 @@ func TestSetField_on_inner_struct_with_valid_value_type(t *testing.T) {
 	assert.Equal(t, dummyStruct.Nested.Nested.Dummy, "abc")
 }
 
+func TestSetField_on_nested_nil_pointer_struct(t *testing.T) {
+	dummyStruct := TestNestedPointerStruct{}
+
+	err := SetField(&dummyStruct, "Nested.Dummy", "nested")
+	assert.NoError(t, err)
+	assert.Equal(t, &NestedStruct{Dummy: "nested"}, dummyStruct.Nested)
+}
+
+func TestSetField_on_pointer_to_non_struct(t *testing.T) {
+	dummyStruct := TestPointerFieldsStruct{}
+
+	err := SetField(&dummyStruct, "Dummy.Value", "test")
+	assert.Error(t, err)
+	assert.Nil(t, dummyStruct.Dummy)
+}
+
+func TestSetField_on_invalid_paths_below_nil_pointers(t *testing.T) {
+	var other struct {
+		Ptr    *TestNestedStruct
+		Double **NestedStruct
+		Any    interface{}
+		Name   string
+	}
+
+	err := SetField(&other, "Ptr.Nested.Missing", "value")
+	assert.EqualError(t, err, "No such field: Ptr.Nested.Missing in obj")
+	assert.Nil(t, other.Ptr)
+
+	err = SetField(&other, "Ptr.Dummy[0]", "value")
+	assert.EqualError(t, err, "No such field: Ptr.Dummy[0] in obj")
+	assert.Nil(t, other.Ptr)
+
+	err = SetField(&other, "Ptr.Nested.Yummy", "value")
+	assert.EqualError(t, err, "Provided value type (string) didn't match obj field type (int)\n")
+	assert.Nil(t, other.Ptr)
+
+	assert.EqualError(t, SetField(&other, "Double.Dummy", "value"), "No such field: Double.Dummy in obj")
+	assert.Nil(t, other.Double)
+	assert.EqualError(t, SetField(&other, "Any.Dummy", "value"), "No such field: Any.Dummy in obj")
+	assert.Nil(t, other.Any)
+	assert.EqualError(t, SetField(&other, "Name.Dummy", "value"), "No such field: Name.Dummy in obj")
+	assert.Empty(t, other.Name)
+
+	assert.NoError(t, SetField(&other, "Ptr.Nested.Yummy", 1))
+	assert.Equal(t, &TestNestedStruct{Nested: NestedStruct{Yummy: 1}}, other.Ptr)
+}
+
 func TestSetField_non_existing_field(t *testing.T) {
 	dummyStruct := TestStruct{
 		Dummy: "test",
@@ -516,6 +697,30 @@ func TestCopyField_from_structs(t *testing.T) {
 	assert.Equal(t, fromStruct.Dummy, toStruct.Dummy)
 }
 
+func TestCopyField_on_optionals(t *testing.T) {
+	type optionalStruct struct {
+		O     Optional[int]
+		Plain int
+	}
+
+	to := optionalStruct{O: Some(5)}
+	assert.NoError(t, CopyField(optionalStruct{}, &to, "O"))
+	assert.Equal(t, Some(5), to.O)
+
+	assert.NoError(t, CopyField(optionalStruct{O: Null[int]()}, &to, "O"))
+	assert.Equal(t, Null[int](), to.O)
+
+	assert.NoError(t, CopyField(optionalStruct{O: Some(7)}, &to, "O"))
+	assert.Equal(t, Some(7), to.O)
+
+	type plainStruct struct{ O int }
+	plain := plainStruct{O: 5}
+	assert.NoError(t, CopyField(optionalStruct{}, &plain, "O"))
+	assert.Equal(t, 5, plain.O)
+	assert.NoError(t, CopyField(optionalStruct{O: Null[int]()}, &plain, "O"))
+	assert.Equal(t, 0, plain.O)
+}
+
 func TestCopyField_with_error(t *testing.T) {
 	fromStruct := "test"
 
@@ -523,6 +728,9 @@ func TestCopyField_with_error(t *testing.T) {
 
 	err := CopyField(fromStruct, &toStruct, "unexported")
 	assert.Error(t, err)
+
+	err = CopyField(TestStruct{unexported: 1}, &toStruct, "unexported")
+	assert.EqualError(t, err, "Cannot CopyField on a non-exported struct field")
 }
 
 func TestFieldsNames_on_struct(t *testing.T) {
@@ -612,6 +820,80 @@ func TestFieldsNames_with_non_exported_fields(t *testing.T) {
 	assert.Equal(t, fields, []string{"Dummy", "Yummy", "DateTime"})
 }
 
+func TestFieldsNames_on_nested_nil_pointer_struct(t *testing.T) {
+	dummyStruct := TestNestedPointerStruct{
+		Dummy: "test",
+		Yummy: 123,
+	}
+
+	fields, err := FieldsNames(dummyStruct)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Dummy", "Yummy", "Nested"}, fields)
+}
+
+func TestFieldsNames_on_pointer_to_non_struct_fields(t *testing.T) {
+	dummy := "test"
+	dummyStruct := TestPointerFieldsStruct{
+		Dummy: &dummy,
+	}
+
+	fields, err := FieldsNames(dummyStruct)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Dummy", "Yummy"}, fields)
+}
+
+func TestFieldsNames_on_cyclic_struct(t *testing.T) {
+	dummyStruct := &TestRecursiveStruct{Dummy: "first"}
+	dummyStruct.Next = &TestRecursiveStruct{Dummy: "second", Next: dummyStruct}
+
+	fields, err := FieldsNames(dummyStruct)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Dummy", "Next", "Next.Dummy", "Next.Next"}, fields)
+}
+
+func TestFieldsNamesWithOptions_max_depth(t *testing.T) {
+	dummyStruct := TestInnerStruct{}
+
+	fields, err := FieldsNamesWithOptions(dummyStruct, TraverseOptions{MaxDepth: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Dummy", "Yummy", "Nested", "Nested.Dummy", "Nested.Yummy", "Nested.Nested"}, fields)
+}
+
+func TestFieldsNamesWithOptions_expand_nil_pointers(t *testing.T) {
+	dummyStruct := TestNestedPointerStruct{}
+
+	fields, err := FieldsNamesWithOptions(dummyStruct, TraverseOptions{ExpandNilPointers: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Dummy", "Yummy", "Nested", "Nested.Dummy", "Nested.Yummy"}, fields)
+}
+
+func TestFieldsNamesWithOptions_on_non_struct_pointer(t *testing.T) {
+	dummy := 123
+
+	_, err := FieldsNamesWithOptions(&dummy, DefaultTraverseOptions)
+	assert.Error(t, err)
+}
+
+func TestTypeFieldsNames_on_recursive_type(t *testing.T) {
+	fields, err := TypeFieldsNames(reflect.TypeOf(TestRecursiveStruct{}), DefaultTraverseOptions)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Dummy", "Next", "Next.Dummy", "Next.Next"}, fields)
+}
+
+func TestTypeFieldsNames_on_pointer_type(t *testing.T) {
+	fields, err := TypeFieldsNames(reflect.TypeOf(&TestNestedPointerStruct{}), TraverseOptions{MaxDepth: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Dummy", "Yummy", "Nested"}, fields)
+}
+
+func TestTypeFieldsNames_on_non_struct(t *testing.T) {
+	_, err := TypeFieldsNames(reflect.TypeOf(""), DefaultTraverseOptions)
+	assert.Error(t, err)
+
+	_, err = TypeFieldsNames(nil, DefaultTraverseOptions)
+	assert.Error(t, err)
+}
+
 func TestFields_on_struct(t *testing.T) {
 	dummyStruct := TestStruct{
 		Dummy: "test",
@@ -726,6 +1008,26 @@ func TestFields_on_nested_pointer_struct_pointer(t *testing.T) {
 	}
 }
 
+func TestStructFieldsWithOptions_on_cyclic_struct(t *testing.T) {
+	dummyStruct := &TestRecursiveStruct{}
+	dummyStruct.Next = dummyStruct
+
+	fields, err := StructFieldsWithOptions(dummyStruct, DefaultTraverseOptions)
+	assert.NoError(t, err)
+	expFields := []string{"Dummy", "Next"}
+	assert.Equal(t, len(expFields), len(fields))
+	for i, field := range fields {
+		assert.Equal(t, expFields[i], field.Name)
+	}
+}
+
+func TestStructFieldsWithOptions_on_non_struct(t *testing.T) {
+	dummy := "abc 123"
+
+	_, err := StructFieldsWithOptions(dummy, DefaultTraverseOptions)
+	assert.Error(t, err)
+}
+
 func TestHasField_on_struct_with_existing_field(t *testing.T) {
 	dummyStruct := TestStruct{
 		Dummy: "test",
@@ -815,6 +1117,41 @@ func TestTags_on_non_struct(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestTagsWithOptions_on_nested_struct(t *testing.T) {
+	dummyStruct := TestCollectionsStruct{
+		Slice: []NestedStruct{{}},
+	}
+
+	tags, err := TagsWithOptions(dummyStruct, "test", TraverseOptions{ExpandNilPointers: true, WalkCollections: true})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"Dummy":               "dummytag",
+		"Slice":               "slicetag",
+		"Slice[0].Dummy":      "dummytag",
+		"Slice[0].Yummy":      "yummytag",
+		"Pointers":            "pointerstag",
+		"Array":               "arraytag",
+		"Array[0].Dummy":      "dummytag",
+		"Array[0].Yummy":      "yummytag",
+		"Map":                 "maptag",
+		"Matrix":              "matrixtag",
+		"Strings":             "stringstag",
+		"Nested":              "nestedtag",
+		"Nested.Dummy":        "dummytag",
+		"Nested.Yummy":        "yummytag",
+		"Nested.Nested":       "",
+		"Nested.Nested.Dummy": "dummytag",
+		"Nested.Nested.Yummy": "yummytag",
+	}, tags)
+}
+
+func TestTagsWithOptions_on_non_struct(t *testing.T) {
+	dummy := "abc 123"
+
+	_, err := TagsWithOptions(dummy, "test", DefaultTraverseOptions)
+	assert.Error(t, err)
+}
+
 func TestItems_on_struct(t *testing.T) {
 	now := time.Now()
 	dummyStruct := TestStruct{
@@ -849,6 +1186,96 @@ func TestItems_on_struct_pointer(t *testing.T) {
 	})
 }
 
+func TestItemsWithOptions_on_nested_pointer_struct(t *testing.T) {
+	dummyStruct := &TestNestedPointerStruct{
+		Dummy:  "test",
+		Nested: &NestedStruct{Dummy: "nested", Yummy: 1},
+	}
+
+	items, err := ItemsWithOptions(dummyStruct, DefaultTraverseOptions)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"Dummy":        "test",
+		"Yummy":        0,
+		"Nested":       dummyStruct.Nested,
+		"Nested.Dummy": "nested",
+		"Nested.Yummy": 1,
+	}, items)
+}
+
+func TestItemsWithOptions_on_nil_pointers(t *testing.T) {
+	dummyStruct := TestNestedPointerStruct{}
+
+	items, err := ItemsWithOptions(dummyStruct, TraverseOptions{ExpandNilPointers: true})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"Dummy":        "",
+		"Yummy":        0,
+		"Nested":       (*NestedStruct)(nil),
+		"Nested.Dummy": nil,
+		"Nested.Yummy": nil,
+	}, items)
+}
+
+func TestItemsWithOptions_walking_collections(t *testing.T) {
+	dummyStruct := TestCollectionsStruct{
+		Slice:    []NestedStruct{{Dummy: "s0"}, {Dummy: "s1"}},
+		Pointers: []*NestedStruct{{Dummy: "p0"}, nil},
+		Array:    [1]NestedStruct{{Dummy: "a0"}},
+		Map:      map[string]NestedStruct{"b": {Dummy: "mb"}, "a": {Dummy: "ma"}},
+		Matrix:   [][]NestedStruct{{{Dummy: "m00"}}},
+		Strings:  []string{"x"},
+	}
+
+	items, err := ItemsWithOptions(dummyStruct, TraverseOptions{WalkCollections: true, MaxDepth: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, "s1", items["Slice[1].Dummy"])
+	assert.Equal(t, "p0", items["Pointers[0].Dummy"])
+	assert.NotContains(t, items, "Pointers[1].Dummy")
+	assert.Equal(t, "a0", items["Array[0].Dummy"])
+	assert.Equal(t, "ma", items["Map[a].Dummy"])
+	assert.Equal(t, "mb", items["Map[b].Dummy"])
+	assert.Equal(t, "m00", items["Matrix[0][0].Dummy"])
+	assert.Equal(t, []string{"x"}, items["Strings"])
+	assert.NotContains(t, items, "Strings[0]")
+
+	fields, err := FieldsNamesWithOptions(dummyStruct, TraverseOptions{WalkCollections: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"Dummy",
+		"Slice", "Slice[0].Dummy", "Slice[0].Yummy", "Slice[1].Dummy", "Slice[1].Yummy",
+		"Pointers", "Pointers[0].Dummy", "Pointers[0].Yummy",
+		"Array", "Array[0].Dummy", "Array[0].Yummy",
+		"Map", "Map[a].Dummy", "Map[a].Yummy", "Map[b].Dummy", "Map[b].Yummy",
+		"Matrix", "Matrix[0][0].Dummy", "Matrix[0][0].Yummy",
+		"Strings",
+		"Nested",
+	}, fields)
+}
+
+func TestFieldsNames_not_walking_collections(t *testing.T) {
+	dummyStruct := TestCollectionsStruct{
+		Slice: []NestedStruct{{}},
+		Map:   map[string]NestedStruct{"a": {}},
+	}
+	expFields := []string{"Dummy", "Slice", "Pointers", "Array", "Map", "Matrix", "Strings", "Nested"}
+
+	fields, err := FieldsNames(dummyStruct)
+	assert.NoError(t, err)
+	assert.Equal(t, expFields, fields)
+
+	fields, err = TypeFieldsNames(reflect.TypeOf(dummyStruct), TraverseOptions{WalkCollections: true, MaxDepth: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, expFields, fields)
+}
+
+func TestItemsWithOptions_on_non_struct(t *testing.T) {
+	dummy := "abc 123"
+
+	_, err := ItemsWithOptions(dummy, DefaultTraverseOptions)
+	assert.Error(t, err)
+}
+
 func TestItems_on_non_struct(t *testing.T) {
 	dummy := "abc 123"
 
@@ -988,6 +1415,22 @@ func TestCopy_on_non_pointer(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestCopy_from_nil(t *testing.T) {
+	dummyStruct := TestStruct{Dummy: "test"}
+	m := map[string]interface{}{"Dummy": "test"}
+
+	err := Copy((*TestStruct)(nil), &dummyStruct)
+	assert.EqualError(t, err, "Cannot copy nil into reflectme.TestStruct")
+	assert.Equal(t, TestStruct{Dummy: "test"}, dummyStruct)
+
+	err = Copy((*TestStruct)(nil), &m)
+	assert.EqualError(t, err, "Cannot copy nil into map[string]interface {}")
+	assert.Equal(t, map[string]interface{}{"Dummy": "test"}, m)
+
+	err = Copy(nil, &dummyStruct)
+	assert.Error(t, err)
+}
+
 func TestCopy_on_non_struct(t *testing.T) {
 	DefaultCopyOptions.IgnoreNotFoundFields = true
 	dummyStruct1 := TestStruct{
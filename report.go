@@ -0,0 +1,151 @@
+package reflectme
+
+import (
+	"errors"
+	"reflect"
+)
+
+// CopyReport lists the paths handled by a copy. Struct fields and map
+// keys are listed by path, while slices, arrays and maps of structs are
+// listed as a whole.
+type CopyReport struct {
+	// Copied are the paths set in the destination
+	Copied []string
+	// SkippedZero are the paths not copied because of their zero value
+	SkippedZero []string
+	// NotFound are the paths missing in the destination
+	NotFound []string
+	// TypeMismatched are the paths whose destination type doesn't match
+	TypeMismatched []string
+}
+
+// CopyWithReport copies like CopyWithOptions and returns the CopyReport
+// of the copy, including the paths failed when IgnoreNotFoundFields is
+// true. On error, the report lists the paths handled until then.
+func CopyWithReport(from interface{}, to interface{}, options CopyOptions) (*CopyReport, error) {
+	report := &CopyReport{}
+	options.report = report
+	err := CopyWithOptions(from, to, options)
+
+	return report, err
+}
+
+// Plan returns the CopyReport a copy from a fromType value into
+// a toType value would have with CopyZeroValues, without needing
+// values. fromType and toType can whether be a struct or pointer to
+// struct. The fields of nil pointers are planned too.
+func Plan(fromType reflect.Type, toType reflect.Type, options CopyOptions) (*CopyReport, error) {
+	fromFields, err := TypeFieldsNames(fromType, DefaultTraverseOptions)
+	if err != nil {
+		return nil, err
+	}
+	if toType == nil || structType(toType) == nil {
+		return nil, errors.New("Cannot use Plan on a non-struct type")
+	}
+	if err := checkSelectedPaths(fromType, options.Include, options.Exclude); err != nil {
+		return nil, err
+	}
+
+	report := &CopyReport{}
+	for i, field := range fromFields {
+		hasNested := i+1 < len(fromFields) && isNestedPath(field, fromFields[i+1])
+		if !options.selects(field, hasNested) {
+			continue
+		}
+		// Listed by TypeFieldsNames so should never get an error
+		fieldType, _ := resolveTypePath(fromType, field, PathOptions{})
+		toFieldType, err := resolveTypePath(toType, field, PathOptions{})
+		switch {
+		case err != nil:
+			report.NotFound = append(report.NotFound, field)
+		case fieldType != toFieldType && copiesNestedPaths(toType, field, hasNested):
+			continue
+		case fieldType == toFieldType,
+			isOptionalCopyableType(fieldType, toFieldType),
+			isCollectionKind(toFieldType.Kind()) && isCopyableType(fieldType, toFieldType):
+			report.Copied = append(report.Copied, field)
+		default:
+			report.TypeMismatched = append(report.TypeMismatched, field)
+		}
+	}
+
+	return report, nil
+}
+
+// copied records a copied path, when reporting
+func (r *CopyReport) copied(path string) {
+	if r != nil {
+		r.Copied = append(r.Copied, path)
+	}
+}
+
+// skippedZero records a path skipped because of its zero value, when
+// reporting
+func (r *CopyReport) skippedZero(path string) {
+	if r != nil {
+		r.SkippedZero = append(r.SkippedZero, path)
+	}
+}
+
+// failed records a path that couldn't be copied into to, when reporting
+func (r *CopyReport) failed(to interface{}, path string) {
+	if r == nil {
+		return
+	}
+	if _, err := resolveTypePath(reflect.TypeOf(to), path, PathOptions{}); err != nil {
+		r.NotFound = append(r.NotFound, path)
+		return
+	}
+	r.TypeMismatched = append(r.TypeMismatched, path)
+}
+
+// copiesNestedPaths indicates if the path, which has nested paths, is a
+// struct or pointer to struct in toType, so its nested paths are copied
+// and reported one by one when it can't be copied as a whole
+func copiesNestedPaths(toType reflect.Type, path string, hasNested bool) bool {
+	if !hasNested {
+		return false
+	}
+	t, err := resolveTypePath(toType, path, PathOptions{})
+
+	return err == nil && structType(t) != nil
+}
+
+// isCopyableType indicates if copyValue can copy a from value into
+// a t value. Interfaces are only known with values, so they are
+// considered copyable.
+func isCopyableType(from reflect.Type, t reflect.Type) bool {
+	fromKind := from.Kind()
+	switch {
+	case fromKind == reflect.Interface:
+		return true
+	case (fromKind == reflect.Slice || fromKind == reflect.Array) && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array):
+		return isCopyableType(from.Elem(), t.Elem())
+	case fromKind == reflect.Map && t.Kind() == reflect.Map:
+		return isConvertibleType(from.Key(), t.Key()) && isCopyableType(from.Elem(), t.Elem())
+	case from.AssignableTo(t):
+		return true
+	case structType(t) != nil:
+		return structType(from) != nil || (fromKind == reflect.Map && from.Key().Kind() == reflect.String)
+	}
+
+	return false
+}
+
+// isOptionalCopyableType indicates if SetField sets a from value into
+// a t field when either is an Optional. Optional values are converted
+// to the t Optional type and unwrapped into plain t fields.
+func isOptionalCopyableType(from reflect.Type, t reflect.Type) bool {
+	if isOptionalType(from) {
+		from = reflect.Zero(from).Interface().(optional).optionalElem()
+		if !isOptionalType(t) {
+			return from == t
+		}
+	}
+	if !isOptionalType(t) {
+		return false
+	}
+	elem := reflect.Zero(t).Interface().(optional).optionalElem()
+
+	return from.AssignableTo(elem) || isConvertibleType(from, elem)
+}
@@ -0,0 +1,236 @@
+package reflectme
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TestReportFrom struct {
+	ID       int
+	Name     string
+	Count    int
+	Removed  string
+	Scores   []int
+	Children []TestModel
+	Address  *NestedStruct
+}
+
+type TestReportTo struct {
+	ID       int
+	Name     string
+	Count    string
+	Scores   []string
+	Children []TestDTO
+	Address  *NestedStruct
+}
+
+func TestCopyWithReport(t *testing.T) {
+	from := TestReportFrom{
+		ID:       1,
+		Count:    2,
+		Removed:  "removed",
+		Scores:   []int{1},
+		Children: []TestModel{{ID: 2, Internal: "internal"}},
+		Address:  &NestedStruct{Dummy: "dummy"},
+	}
+	to := TestReportTo{}
+
+	report, err := CopyWithReport(from, &to, CopyOptions{IgnoreNotFoundFields: true})
+	assert.NoError(t, err)
+	assert.Equal(t, &CopyReport{
+		Copied:         []string{"ID", "Children", "Address", "Address.Dummy"},
+		SkippedZero:    []string{"Name", "Address.Yummy"},
+		NotFound:       []string{"Removed"},
+		TypeMismatched: []string{"Count", "Scores"},
+	}, report)
+	assert.Equal(t, []TestDTO{{ID: 2}}, to.Children)
+}
+
+func TestCopyWithReport_on_error(t *testing.T) {
+	report, err := CopyWithReport(TestReportFrom{ID: 1, Count: 2}, &TestReportTo{}, CopyOptions{})
+	assert.Error(t, err)
+	assert.Equal(t, &CopyReport{
+		Copied:         []string{"ID"},
+		SkippedZero:    []string{"Name"},
+		TypeMismatched: []string{"Count"},
+	}, report)
+
+	report, err = CopyWithReport(TestReportFrom{}, TestReportTo{}, CopyOptions{})
+	assert.Error(t, err)
+	assert.Equal(t, &CopyReport{}, report)
+}
+
+func TestCopyWithReport_with_maps(t *testing.T) {
+	report, err := CopyWithReport(
+		map[string]interface{}{"Dummy": "dummy", "Yummy": 0, "Other": 1},
+		&NestedStruct{},
+		CopyOptions{IgnoreNotFoundFields: true},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, &CopyReport{
+		Copied:      []string{"Dummy"},
+		SkippedZero: []string{"Yummy"},
+		NotFound:    []string{"Other"},
+	}, report)
+
+	report, err = CopyWithReport(map[string]interface{}{"Yummy": "yummy"}, &NestedStruct{}, CopyOptions{})
+	assert.Error(t, err)
+	assert.Equal(t, &CopyReport{TypeMismatched: []string{"Yummy"}}, report)
+
+	m := map[string]interface{}{}
+	report, err = CopyWithReport(NestedStruct{Dummy: "dummy"}, &m, CopyOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, &CopyReport{Copied: []string{"Dummy"}, SkippedZero: []string{"Yummy"}}, report)
+
+	m = map[string]interface{}{}
+	report, err = CopyWithReport(map[string]interface{}{"a": 1, "b": 0}, &m, CopyOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, &CopyReport{Copied: []string{"a"}, SkippedZero: []string{"b"}}, report)
+}
+
+func TestPlan(t *testing.T) {
+	report, err := Plan(reflect.TypeOf(TestReportFrom{}), reflect.TypeOf(&TestReportTo{}), CopyOptions{
+		Exclude: []string{"Address.Yummy"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &CopyReport{
+		Copied:         []string{"ID", "Name", "Children", "Address.Dummy"},
+		NotFound:       []string{"Removed"},
+		TypeMismatched: []string{"Count", "Scores"},
+	}, report)
+}
+
+func TestCopyWithReport_on_nested_structs_of_other_types(t *testing.T) {
+	type fromAddress struct {
+		Zip     string
+		Removed string
+	}
+	type toAddress struct {
+		Zip string
+	}
+	type fromStruct struct {
+		Nested  fromAddress
+		Pointer *fromAddress
+	}
+	type toStruct struct {
+		Nested  toAddress
+		Pointer *toAddress
+	}
+
+	from := fromStruct{Nested: fromAddress{Zip: "zip"}, Pointer: &fromAddress{Zip: "pointer", Removed: "removed"}}
+	var to toStruct
+	report, err := CopyWithReport(from, &to, CopyOptions{IgnoreNotFoundFields: true})
+	assert.NoError(t, err)
+	assert.Equal(t, &CopyReport{
+		Copied:      []string{"Nested.Zip", "Pointer.Zip"},
+		SkippedZero: []string{"Nested.Removed"},
+		NotFound:    []string{"Pointer.Removed"},
+	}, report)
+	assert.Equal(t, toStruct{Nested: toAddress{Zip: "zip"}, Pointer: &toAddress{Zip: "pointer"}}, to)
+
+	// Only the leaves fail the copy
+	to = toStruct{}
+	report, err = CopyWithReport(fromStruct{Nested: fromAddress{Zip: "zip"}}, &to, CopyOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, &CopyReport{
+		Copied:      []string{"Nested.Zip"},
+		SkippedZero: []string{"Nested.Removed", "Pointer"},
+	}, report)
+
+	report, err = Plan(reflect.TypeOf(from), reflect.TypeOf(to), CopyOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, &CopyReport{
+		Copied:   []string{"Nested.Zip", "Pointer.Zip"},
+		NotFound: []string{"Nested.Removed", "Pointer.Removed"},
+	}, report)
+
+	// Other destination types are still mismatched
+	report, err = Plan(reflect.TypeOf(from), reflect.TypeOf(struct{ Nested string }{}), CopyOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Nested"}, report.TypeMismatched)
+}
+
+func TestPlan_on_optionals(t *testing.T) {
+	type fromStruct struct {
+		Unwrapped Optional[int]
+		Wrapped   int32
+		Converted Optional[int32]
+		Other     Optional[int32]
+		Text      string
+	}
+	type toStruct struct {
+		Unwrapped int
+		Wrapped   Optional[int]
+		Converted Optional[int64]
+		Other     int
+		Text      Optional[int]
+	}
+	from := fromStruct{Unwrapped: Some(1), Wrapped: 2, Converted: Some(int32(3)), Other: Some(int32(4)), Text: "text"}
+
+	report, err := CopyWithReport(from, &toStruct{}, CopyOptions{IgnoreNotFoundFields: true, CopyZeroValues: true})
+	assert.NoError(t, err)
+	plan, err := Plan(reflect.TypeOf(from), reflect.TypeOf(toStruct{}), CopyOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, report, plan)
+	assert.Equal(t, []string{"Unwrapped", "Wrapped", "Converted"}, plan.Copied)
+	assert.Equal(t, []string{"Other", "Text"}, plan.TypeMismatched)
+}
+
+func TestPlan_on_invalid_types(t *testing.T) {
+	_, err := Plan(nil, reflect.TypeOf(TestReportTo{}), CopyOptions{})
+	assert.Error(t, err)
+
+	_, err = Plan(reflect.TypeOf(1), reflect.TypeOf(TestReportTo{}), CopyOptions{})
+	assert.Error(t, err)
+
+	_, err = Plan(reflect.TypeOf(TestReportFrom{}), nil, CopyOptions{})
+	assert.EqualError(t, err, "Cannot use Plan on a non-struct type")
+
+	_, err = Plan(reflect.TypeOf(TestReportFrom{}), reflect.TypeOf(1), CopyOptions{})
+	assert.EqualError(t, err, "Cannot use Plan on a non-struct type")
+}
+
+func TestIsCopyableType(t *testing.T) {
+	cases := []struct {
+		from     interface{}
+		to       interface{}
+		copyable bool
+	}{
+		{[]interface{}{}, []int{}, true},
+		{[2]int{}, []int{}, true},
+		{[2]TestModel{}, []*TestDTO{}, true},
+		{map[int32]TestModel{}, map[int64]TestDTO{}, true},
+		{map[string]map[string]interface{}{}, map[string]NestedStruct{}, true},
+		{map[string]int{}, map[int]int{}, false},
+		{map[string]int{}, map[string]string{}, false},
+		{[]int{}, []NestedStruct{}, false},
+		{[]int{}, map[string]int{}, false},
+	}
+	for _, c := range cases {
+		from, to := reflect.TypeOf(c.from), reflect.TypeOf(c.to)
+		assert.Equal(t, c.copyable, isCopyableType(from, to), "%v to %v", from, to)
+	}
+}
+
+func TestIsOptionalCopyableType(t *testing.T) {
+	cases := []struct {
+		from     interface{}
+		to       interface{}
+		copyable bool
+	}{
+		{Optional[int]{}, 0, true},
+		{Optional[int]{}, int64(0), false},
+		{0, Optional[int]{}, true},
+		{int8(0), Optional[int]{}, true},
+		{"", Optional[int]{}, false},
+		{Optional[int8]{}, Optional[int]{}, true},
+		{Optional[string]{}, Optional[int]{}, false},
+		{0, 0, false},
+	}
+	for _, c := range cases {
+		from, to := reflect.TypeOf(c.from), reflect.TypeOf(c.to)
+		assert.Equal(t, c.copyable, isOptionalCopyableType(from, to), "%v to %v", from, to)
+	}
+}
@@ -0,0 +1,112 @@
+package reflectme
+
+import (
+	"errors"
+	"reflect"
+)
+
+type (
+	// SchemaField describes a struct field as known from its type only
+	SchemaField struct {
+		// Name is the field name
+		Name string
+		// Path is the dotted field name from the root struct as
+		// accepted by GetField
+		Path string
+		// Type is the field type
+		Type reflect.Type
+		// Kind is the field type kind
+		Kind reflect.Kind
+		// Tag is the whole field tag
+		Tag reflect.StructTag
+		// Offset is the field offset within its struct, in bytes
+		Offset uintptr
+		// Index is the index sequence for reflect.Type.FieldByIndex
+		// within the struct holding the field
+		Index []int
+		// Exported indicates if the field is exported
+		Exported bool
+		// Embedded indicates if the field is an embedded field
+		Embedded bool
+		// Optional indicates if the field is a pointer or an interface
+		// and so may hold no value
+		Optional bool
+		// Recursive indicates if the field type is a struct already
+		// expanded in the current path, so its Fields are not listed again
+		Recursive bool
+		// Fields are the nested fields when the field is a struct or
+		// a pointer to struct
+		Fields []SchemaField
+	}
+)
+
+// Schema returns the fields tree of a struct type without needing an
+// instance of it. t can whether be a structure or pointer to structure
+// type. Pointer to struct fields are fully expanded and recursive types
+// are marked as Recursive instead of being expanded again. Unexported
+// fields are listed but never expanded.
+func Schema(t reflect.Type) ([]SchemaField, error) {
+	if t == nil {
+		return nil, errors.New("Cannot use Schema on a nil type")
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, errors.New("Cannot use Schema on a non-struct type")
+	}
+
+	return schemaFields(t, "", map[reflect.Type]bool{t: true}), nil
+}
+
+func schemaFields(t reflect.Type, parent string, path map[reflect.Type]bool) []SchemaField {
+	fieldsCount := t.NumField()
+	fields := make([]SchemaField, 0, fieldsCount)
+	for i := 0; i < fieldsCount; i++ {
+		field := t.Field(i)
+		fieldName := field.Name
+		if len(parent) > 0 {
+			fieldName = parent + "." + fieldName
+		}
+
+		kind := field.Type.Kind()
+		schemaField := SchemaField{
+			Name:     field.Name,
+			Path:     fieldName,
+			Type:     field.Type,
+			Kind:     kind,
+			Tag:      field.Tag,
+			Offset:   field.Offset,
+			Index:    field.Index,
+			Exported: isExportableField(field),
+			Embedded: field.Anonymous,
+			Optional: kind == reflect.Ptr || kind == reflect.Interface,
+		}
+
+		if nestedType := structType(field.Type); nestedType != nil && schemaField.Exported {
+			if path[nestedType] {
+				schemaField.Recursive = true
+			} else {
+				path[nestedType] = true
+				schemaField.Fields = schemaFields(nestedType, fieldName, path)
+				delete(path, nestedType)
+			}
+		}
+		fields = append(fields, schemaField)
+	}
+
+	return fields
+}
+
+// structType returns the struct type of t when t is a struct or
+// a pointer to struct, otherwise nil.
+func structType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	return t
+}
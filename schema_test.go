@@ -0,0 +1,71 @@
+package reflectme
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TestEmbeddedStruct struct {
+	NestedStruct
+	Other *NestedStruct `json:"other"`
+}
+
+func TestSchema_on_struct(t *testing.T) {
+	schema, err := Schema(reflect.TypeOf(TestStruct{}))
+	assert.NoError(t, err)
+	assert.Equal(t, 4, len(schema))
+
+	assert.Equal(t, "unexported", schema[0].Name)
+	assert.False(t, schema[0].Exported)
+	assert.Equal(t, reflect.Uint64, schema[0].Kind)
+
+	assert.Equal(t, "Dummy", schema[1].Path)
+	assert.True(t, schema[1].Exported)
+	assert.Equal(t, "dummytag", schema[1].Tag.Get("test"))
+	assert.Equal(t, reflect.TypeOf(""), schema[1].Type)
+	assert.Equal(t, uintptr(8), schema[1].Offset)
+	assert.Equal(t, []int{1}, schema[1].Index)
+	assert.False(t, schema[1].Optional)
+}
+
+func TestSchema_on_nested_nil_pointer_struct(t *testing.T) {
+	schema, err := Schema(reflect.TypeOf(&TestNestedPointerStruct{}))
+	assert.NoError(t, err)
+
+	nested := schema[3]
+	assert.Equal(t, "Nested", nested.Path)
+	assert.Equal(t, reflect.Ptr, nested.Kind)
+	assert.True(t, nested.Optional)
+	assert.Equal(t, 2, len(nested.Fields))
+	assert.Equal(t, "Nested.Dummy", nested.Fields[0].Path)
+	assert.Equal(t, "Nested.Yummy", nested.Fields[1].Path)
+}
+
+func TestSchema_on_embedded_struct(t *testing.T) {
+	schema, err := Schema(reflect.TypeOf(TestEmbeddedStruct{}))
+	assert.NoError(t, err)
+
+	assert.True(t, schema[0].Embedded)
+	assert.Equal(t, "NestedStruct.Dummy", schema[0].Fields[0].Path)
+	assert.False(t, schema[1].Embedded)
+	assert.Equal(t, "other", schema[1].Tag.Get("json"))
+}
+
+func TestSchema_on_recursive_struct(t *testing.T) {
+	schema, err := Schema(reflect.TypeOf(TestRecursiveStruct{}))
+	assert.NoError(t, err)
+
+	next := schema[1]
+	assert.True(t, next.Recursive)
+	assert.Empty(t, next.Fields)
+}
+
+func TestSchema_on_non_struct(t *testing.T) {
+	_, err := Schema(reflect.TypeOf(""))
+	assert.Error(t, err)
+
+	_, err = Schema(nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,194 @@
+package reflectme
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type (
+	// Tag is a struct tag key value following the json like
+	// `name,opt1,opt2` convention
+	Tag struct {
+		// Key is the tag key, eg "json"
+		Key string
+		// Name is the value part before the first comma
+		Name string
+		// Options are the value parts after the first comma
+		Options []string
+	}
+
+	// StructTag is a parsed struct tag. Keys keep their original order.
+	StructTag struct {
+		tags []Tag
+	}
+)
+
+// ParseTag parses a whole struct tag like `json:"name,omitempty" db:"name"`.
+// Values are split by commas except inside single quotes, so
+// `default:"'a,b',c"` has the "'a,b'" name and the "c" option.
+func ParseTag(tag reflect.StructTag) (*StructTag, error) {
+	st := &StructTag{}
+	s := strings.TrimLeft(string(tag), " ")
+	for s != "" {
+		i := 0
+		for i < len(s) && s[i] > ' ' && s[i] != ':' && s[i] != '"' && s[i] != 0x7f {
+			i++
+		}
+		if i == 0 || i+1 >= len(s) || s[i] != ':' || s[i+1] != '"' {
+			return nil, fmt.Errorf("Invalid struct tag syntax: %s", tag)
+		}
+		key := s[:i]
+		s = s[i+1:]
+
+		// Scan the quoted value, skipping escaped chars
+		i = 1
+		for i < len(s) && s[i] != '"' {
+			if s[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(s) {
+			return nil, fmt.Errorf("Invalid struct tag value: %s", tag)
+		}
+		value, err := strconv.Unquote(s[:i+1])
+		if err != nil {
+			return nil, fmt.Errorf("Invalid struct tag value: %s", tag)
+		}
+		s = strings.TrimLeft(s[i+1:], " ")
+
+		// Like reflect.StructTag.Get, the first duplicated key wins
+		if _, ok := st.Get(key); !ok {
+			name, options := parseTagValue(value)
+			st.tags = append(st.tags, Tag{Key: key, Name: name, Options: options})
+		}
+	}
+
+	return st, nil
+}
+
+// GetFieldTags returns the provided obj field parsed tag. obj can whether
+// be a structure or pointer to structure.
+func GetFieldTags(obj interface{}, fieldName string) (*StructTag, error) {
+	field, err := getInnerFieldType(obj, fieldName)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isExportableField(field) {
+		return nil, errors.New("Cannot GetFieldTags on a non-exported struct field")
+	}
+
+	return ParseTag(field.Tag)
+}
+
+// Keys returns the tag keys in their original order
+func (t *StructTag) Keys() []string {
+	keys := make([]string, len(t.tags))
+	for i, tag := range t.tags {
+		keys[i] = tag.Key
+	}
+
+	return keys
+}
+
+// Get returns the tag with the provided key and if it's present
+func (t *StructTag) Get(key string) (Tag, bool) {
+	for _, tag := range t.tags {
+		if tag.Key == key {
+			return tag, true
+		}
+	}
+
+	return Tag{}, false
+}
+
+// Set replaces the tag with the same key or adds it at the end
+func (t *StructTag) Set(tag Tag) {
+	for i := range t.tags {
+		if t.tags[i].Key == tag.Key {
+			t.tags[i] = tag
+			return
+		}
+	}
+	t.tags = append(t.tags, tag)
+}
+
+// Delete removes the tags with the provided keys
+func (t *StructTag) Delete(keys ...string) {
+	tags := t.tags[:0]
+	for _, tag := range t.tags {
+		if !hasOption(keys, tag.Key) {
+			tags = append(tags, tag)
+		}
+	}
+	t.tags = tags
+}
+
+// String returns the struct tag in the `key:"value"` format
+func (t *StructTag) String() string {
+	parts := make([]string, len(t.tags))
+	for i, tag := range t.tags {
+		parts[i] = tag.Key + ":" + strconv.Quote(tag.Value())
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// Value returns the tag value joining name and options
+func (t Tag) Value() string {
+	return strings.Join(append([]string{t.Name}, t.Options...), ",")
+}
+
+// HasOption indicates if the tag has the provided option, eg "omitempty"
+func (t Tag) HasOption(option string) bool {
+	return hasOption(t.Options, option)
+}
+
+// OptionValue returns the value of a `key=value` option and if it's present
+func (t Tag) OptionValue(key string) (string, bool) {
+	for _, option := range t.Options {
+		if strings.HasPrefix(option, key+"=") {
+			return option[len(key)+1:], true
+		}
+	}
+
+	return "", false
+}
+
+// parseTagValue splits a tag value in name and options
+func parseTagValue(value string) (string, []string) {
+	var parts []string
+	var part strings.Builder
+	quoted := false
+	for _, r := range value {
+		if r == ',' && !quoted {
+			parts = append(parts, part.String())
+			part.Reset()
+			continue
+		}
+		if r == '\'' {
+			quoted = !quoted
+		}
+		part.WriteRune(r)
+	}
+	parts = append(parts, part.String())
+	if len(parts) == 1 {
+		return parts[0], nil
+	}
+
+	return parts[0], parts[1:]
+}
+
+func hasOption(options []string, option string) bool {
+	for _, o := range options {
+		if o == option {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,104 @@
+package reflectme
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TestTagsStruct struct {
+	unexported string `test:"unexported"`
+	Name       string `json:"name,omitempty" db:"user_name" validate:"required,min=1,oneof='a,b' c"`
+	Nested     NestedStruct
+}
+
+func TestParseTag(t *testing.T) {
+	tag, err := ParseTag(`json:"name,omitempty"  db:"user_name" quoted:"say \"hi\",x" json:"other"`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"json", "db", "quoted"}, tag.Keys())
+
+	json, ok := tag.Get("json")
+	assert.True(t, ok)
+	assert.Equal(t, Tag{Key: "json", Name: "name", Options: []string{"omitempty"}}, json)
+	assert.True(t, json.HasOption("omitempty"))
+	assert.False(t, json.HasOption("string"))
+
+	quoted, _ := tag.Get("quoted")
+	assert.Equal(t, `say "hi"`, quoted.Name)
+	assert.Equal(t, []string{"x"}, quoted.Options)
+
+	_, ok = tag.Get("xml")
+	assert.False(t, ok)
+}
+
+func TestParseTag_with_single_quotes(t *testing.T) {
+	tag, err := ParseTag(`validate:"required,min=1,oneof='a,b' c"`)
+	assert.NoError(t, err)
+
+	validate, _ := tag.Get("validate")
+	assert.Equal(t, "required", validate.Name)
+	assert.Equal(t, []string{"min=1", "oneof='a,b' c"}, validate.Options)
+
+	value, ok := validate.OptionValue("oneof")
+	assert.True(t, ok)
+	assert.Equal(t, "'a,b' c", value)
+
+	_, ok = validate.OptionValue("max")
+	assert.False(t, ok)
+}
+
+func TestParseTag_empty(t *testing.T) {
+	tag, err := ParseTag("")
+	assert.NoError(t, err)
+	assert.Empty(t, tag.Keys())
+	assert.Equal(t, "", tag.String())
+}
+
+func TestParseTag_invalid(t *testing.T) {
+	invalid := []reflect.StructTag{
+		`json`,
+		`json:name`,
+		`:"name"`,
+		`json:"name`,
+		`json:"\x"`,
+	}
+	for _, tag := range invalid {
+		_, err := ParseTag(tag)
+		assert.Error(t, err, tag)
+	}
+}
+
+func TestStructTag_rewrite(t *testing.T) {
+	tag, err := ParseTag(`json:"name,omitempty" db:"user_name" xml:"name"`)
+	assert.NoError(t, err)
+
+	tag.Set(Tag{Key: "json", Name: "fullName", Options: []string{"omitempty", "string"}})
+	tag.Set(Tag{Key: "yaml", Name: `a"b`})
+	tag.Delete("db", "xml")
+	assert.Equal(t, `json:"fullName,omitempty,string" yaml:"a\"b"`, tag.String())
+
+	reparsed, err := ParseTag(reflect.StructTag(tag.String()))
+	assert.NoError(t, err)
+	assert.Equal(t, tag, reparsed)
+	assert.Equal(t, "fullName,omitempty,string", reflect.StructTag(tag.String()).Get("json"))
+}
+
+func TestGetFieldTags(t *testing.T) {
+	tag, err := GetFieldTags(&TestTagsStruct{}, "Name")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"json", "db", "validate"}, tag.Keys())
+
+	tag, err = GetFieldTags(TestTagsStruct{}, "Nested.Dummy")
+	assert.NoError(t, err)
+	test, _ := tag.Get("test")
+	assert.Equal(t, "dummytag", test.Name)
+}
+
+func TestGetFieldTags_with_error(t *testing.T) {
+	_, err := GetFieldTags(TestTagsStruct{}, "obladioblada")
+	assert.Error(t, err)
+
+	_, err = GetFieldTags(TestTagsStruct{}, "unexported")
+	assert.Error(t, err)
+}